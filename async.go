@@ -0,0 +1,217 @@
+package trees
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// asyncState defines the resolution state of an asynchronous subtree, with
+// the id set as the data-async attribute of its placeholder.
+type asyncState struct {
+	id      string
+	done    chan struct{}
+	content *Markup
+	err     error
+}
+
+// Async returns the placeholder markup for a subtree resolved in the
+// background by the giving function, which is called right away with the
+// context. The placeholder is printed as is by the printers, while Stream
+// flushes the resolved content after the rest of the tree and Await replaces
+// the placeholder with it. Timeouts and cancellation are provided through the
+// context (e.g context.WithTimeout). A nil placeholder uses an empty <div>.
+//
+// The placeholder gets a data-async attribute with an id of its own, which
+// Stream uses to find it in the browser even if its uid changed.
+func Async(ctx context.Context, placeholder *Markup, fn func(context.Context) (*Markup, error)) *Markup {
+	if placeholder == nil {
+		placeholder = NewMarkup("div", false)
+	}
+
+	state := &asyncState{id: RandString(12), done: make(chan struct{})}
+	placeholder.async = state
+	ReplaceORAddAttribute(placeholder, "data-async", state.id)
+
+	go func() {
+		defer close(state.done)
+
+		defer func() {
+			if r := recover(); r != nil {
+				state.err = fmt.Errorf("%v", r)
+			}
+		}()
+
+		content, err := fn(ctx)
+		if err == nil && content == nil {
+			err = ErrNotMarkup
+		}
+
+		state.content, state.err = content, err
+	}()
+
+	return placeholder
+}
+
+// Pending returns true/false if the markup is an asynchronous placeholder
+// which is not resolved yet.
+func (e *Markup) Pending() bool {
+	if e.async == nil {
+		return false
+	}
+
+	select {
+	case <-e.async.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Await waits for all asynchronous placeholders within the root, including
+// those of resolved content, replacing each resolved placeholder with its
+// content. Placeholders which fail or are not resolved before the context is
+// done are left as is and their errors reported to the closest error
+// boundary. The root itself can not be replaced and is left as is.
+func Await(ctx context.Context, root *Markup) error {
+	return resolveAsync(ctx, root, func(placeholder *Markup, content *Markup) error {
+		if parent := placeholder.parent; parent != nil {
+			index := -1
+
+			for ind, child := range parent.children {
+				if child == placeholder {
+					index = ind
+					break
+				}
+			}
+
+			parent.removeChild(index, placeholder)
+			parent.insertChild(index, content)
+		}
+
+		return nil
+	})
+}
+
+// Stream writes the html of the root into the writer, flushing it right
+// away with all asynchronous placeholders, then writes the content of each
+// placeholder in the order they resolve, wrapped in a <template> with a small
+// inline script swapping it with its placeholder in the browser. Writers with
+// a Flush method (e.g http.ResponseWriter) are flushed after every write.
+// Placeholders which fail or are not resolved before the context is done are
+// left as is and their errors reported to the closest error boundary.
+func Stream(ctx context.Context, w io.Writer, root *Markup) error {
+	if err := writeFlush(w, SimpleElementWriter.Print(root)); err != nil {
+		return err
+	}
+
+	return resolveAsync(ctx, root, func(placeholder *Markup, content *Markup) error {
+		return writeFlush(w, fmt.Sprintf(asyncSwap, placeholder.async.id, SimpleElementWriter.Print(content), placeholder.async.id))
+	})
+}
+
+// asyncSwap defines the template and script written by Stream for each
+// resolved placeholder.
+const asyncSwap = `<template id="trees-async-%[1]s">%[2]s</template><script>(function(){` +
+	`var t=document.getElementById("trees-async-%[3]s"),p=document.querySelector("[data-async='%[3]s']");` +
+	`if(t&&p){p.parentNode.replaceChild(t.content.cloneNode(true),p);}if(t){t.parentNode.removeChild(t);}` +
+	`})();</script>`
+
+// resolveAsync waits for the asynchronous placeholders within the root and
+// calls the function with each resolved placeholder and its content in the
+// order they resolve.
+func resolveAsync(ctx context.Context, root *Markup, fn func(placeholder *Markup, content *Markup) error) error {
+	resolved := make(chan *Markup)
+	var pending int
+
+	watch := func(m *Markup) {
+		for _, placeholder := range asyncWithin(m) {
+			pending++
+
+			go func(placeholder *Markup) {
+				select {
+				case <-placeholder.async.done:
+				case <-ctx.Done():
+				}
+
+				resolved <- placeholder
+			}(placeholder)
+		}
+	}
+
+	watch(root)
+
+	var werr error
+
+	for ; pending > 0; pending-- {
+		placeholder := <-resolved
+
+		if placeholder.Pending() {
+			reportAsync(placeholder, ctx.Err())
+			continue
+		}
+
+		state := placeholder.async
+		if state.err != nil {
+			reportAsync(placeholder, state.err)
+			continue
+		}
+
+		if werr != nil {
+			continue
+		}
+
+		if werr = fn(placeholder, state.content); werr == nil {
+			watch(state.content)
+		}
+	}
+
+	return werr
+}
+
+// asyncWithin returns the asynchronous placeholders of the markup and its
+// descendants.
+func asyncWithin(m *Markup) []*Markup {
+	var found []*Markup
+
+	if m.async != nil {
+		found = append(found, m)
+	}
+
+	m.EachChild(func(child *Markup) {
+		if child.async != nil {
+			found = append(found, child)
+		}
+	})
+
+	return found
+}
+
+// reportAsync reports the failure of the placeholder to its closest error
+// boundary.
+func reportAsync(placeholder *Markup, err error) {
+	boundary := closestBoundary(placeholder)
+	if boundary == nil || boundary.boundary.Report == nil {
+		return
+	}
+
+	rerr := newRenderError(placeholder, err)
+	rerr.Boundary = boundary
+	boundary.boundary.Report(rerr)
+}
+
+// writeFlush writes the content into the writer and flushes it if possible.
+func writeFlush(w io.Writer, content string) error {
+	if _, err := io.WriteString(w, content); err != nil {
+		return err
+	}
+
+	switch flusher := w.(type) {
+	case interface{ Flush() error }:
+		return flusher.Flush()
+	case interface{ Flush() }:
+		flusher.Flush()
+	}
+
+	return nil
+}
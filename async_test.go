@@ -0,0 +1,78 @@
+package trees_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gu-io/trees"
+)
+
+func TestAsyncStreaming(t *testing.T) {
+	ctx := context.Background()
+	page := trees.ParseTree(`<div id="page"><h1>Dashboard</h1></div>`)[0]
+
+	release := make(chan struct{})
+	slow := trees.Async(ctx, trees.ParseTree(`<p>Loading stats</p>`)[0], func(ctx context.Context) (*trees.Markup, error) {
+		<-release
+		return trees.ParseTree(`<p>Stats ready</p>`)[0], nil
+	})
+
+	fast := trees.Async(ctx, nil, func(ctx context.Context) (*trees.Markup, error) {
+		return trees.ParseTree(`<p>News ready</p>`)[0], nil
+	})
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	stalled := trees.Async(timeout, nil, func(ctx context.Context) (*trees.Markup, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	var reports []*trees.RenderError
+	trees.NewErrorBoundary(nil, func(rerr *trees.RenderError) {
+		reports = append(reports, rerr)
+	}).Apply(page)
+
+	page.AddChild(slow, fast, stalled)
+
+	// the placeholder keeps its data-async id when its uid changes.
+	attr, _ := trees.GetAttr(slow, "data-async")
+	_, id := attr.Render()
+	slow.SwapUID("swapped")
+
+	var out bytes.Buffer
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		close(release)
+	}()
+
+	if err := trees.Stream(ctx, &out, page); err != nil {
+		t.Fatalf("\t%s\t  Should have streamed markup: %+q", failed, err)
+	}
+
+	html := out.String()
+	loading, news, stats := strings.Index(html, "Loading stats"), strings.Index(html, "News ready"), strings.Index(html, "Stats ready")
+	if loading == -1 || news == -1 || stats == -1 || !(loading < news && news < stats) {
+		t.Fatalf("\t%s\t  Should have streamed placeholders first and content as resolved: %s", failed, html)
+	}
+
+	if id == "" || !strings.Contains(html, `data-async="`+id+`"`) || !strings.Contains(html, `<template id="trees-async-`+id+`">`) || !strings.Contains(html, `data-async='`+id+`'`) {
+		t.Fatalf("\t%s\t  Should have streamed swap templates and scripts: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have streamed content out of order", success)
+
+	if len(reports) != 1 || reports[0].Node != stalled || !errors.Is(reports[0].Err, context.DeadlineExceeded) {
+		t.Fatalf("\t%s\t  Should have reported timed out placeholder: %#v", failed, reports)
+	}
+	t.Logf("\t%s\t  Should have reported timed out placeholder", success)
+
+	if err := trees.Await(ctx, page); err != nil || strings.Contains(page.HTML(), "Loading stats") || !strings.Contains(page.HTML(), "Stats ready") {
+		t.Fatalf("\t%s\t  Should have replaced placeholders with content: %s", failed, page.HTML())
+	}
+	t.Logf("\t%s\t  Should have replaced placeholders with content", success)
+}
@@ -0,0 +1,156 @@
+package trees
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// RenderError defines the error caught by an error boundary, with the markup
+// where the error or panic occured.
+type RenderError struct {
+	Err      error
+	Value    interface{}
+	Node     *Markup
+	Boundary *Markup
+	Selector string
+}
+
+// Error returns the error message with the selector of the failed markup.
+func (r *RenderError) Error() string {
+	return fmt.Sprintf("Render failed at %q: %s", r.Selector, r.Err)
+}
+
+// ErrorBoundary defines a Appliable which turns the markup it is applied to
+// into an error boundary. Panics from the markup and its descendants while
+// morphing or printing, and errors reported during rendering (e.g
+// CSSStylesheet and template errors), are caught by the closest boundary,
+// which reports them to its Report hook and renders its Fallback in place of
+// itself, leaving the rest of the tree intact.
+//
+// A boundary which failed during morphing keeps rendering its fallback until
+// the next successful call to ApplyMorphers.
+type ErrorBoundary struct {
+	Fallback func(*RenderError) *Markup
+	Report   func(*RenderError)
+}
+
+// NewErrorBoundary returns a new ErrorBoundary with the fallback and report
+// hook, both of which can be nil. A nil fallback renders the boundary markup
+// without its text and children.
+func NewErrorBoundary(fallback func(*RenderError) *Markup, report func(*RenderError)) *ErrorBoundary {
+	return &ErrorBoundary{Fallback: fallback, Report: report}
+}
+
+// Apply sets the markup as an error boundary.
+func (b *ErrorBoundary) Apply(e *Markup) {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
+	e.boundary = b
+}
+
+// Boundary returns the ErrorBoundary of the markup, if any.
+func (e *Markup) Boundary() *ErrorBoundary {
+	return e.boundary
+}
+
+// Failure returns the error caught by the markup's error boundary while
+// morphing, if any.
+func (e *Markup) Failure() *RenderError {
+	return e.failure
+}
+
+// catch turns the recovered value into a RenderError for the boundary markup
+// and reports it.
+func (b *ErrorBoundary) catch(boundary *Markup, r interface{}) *RenderError {
+	rerr, ok := r.(*RenderError)
+	if !ok {
+		rerr = newRenderError(boundary, r)
+	}
+
+	rerr.Boundary = boundary
+
+	if b.Report != nil {
+		b.Report(rerr)
+	}
+
+	return rerr
+}
+
+// fallback returns the markup rendered in place of the failed boundary.
+func (b *ErrorBoundary) fallback(boundary *Markup, rerr *RenderError) *Markup {
+	if b.Fallback != nil {
+		if fallback := b.Fallback(rerr); fallback != nil {
+			return fallback
+		}
+	}
+
+	co := NewMarkup(boundary.tagname, boundary.autoclose)
+	co.uid = boundary.uid
+	co.hash = boundary.hash
+	co.attrs = boundary.attrs
+	co.styles = boundary.styles
+	return co
+}
+
+// newRenderError returns a new RenderError for the recovered value of the
+// markup.
+func newRenderError(e *Markup, r interface{}) *RenderError {
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+
+	return &RenderError{Err: err, Value: r, Node: e, Selector: e.IDSelector(false)}
+}
+
+// annotatePanic recovers a panic from the markup to panic again with a
+// RenderError pointing to the markup, unless it already is one. It must only
+// be deferred for markup with an error boundary ancestor, panics of other
+// markup are left as they are.
+func annotatePanic(e *Markup) {
+	if r := recover(); r != nil {
+		if _, ok := r.(*RenderError); ok {
+			panic(r)
+		}
+
+		panic(newRenderError(e, r))
+	}
+}
+
+// closestBoundary returns the closest error boundary of the markup, starting
+// with the markup itself.
+func closestBoundary(e *Markup) *Markup {
+	for node := e; node != nil; node = node.parent {
+		if node.boundary != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// renderError reports the error of the markup's rendering to the closest
+// error boundary if it is being morphed or printed, else it returns the error
+// message as content.
+func renderError(e *Markup, err error) string {
+	if boundary := closestBoundary(e); boundary != nil && atomic.LoadInt32(&boundary.rendering) > 0 {
+		panic(newRenderError(e, err))
+	}
+
+	return err.Error()
+}
+
+// errorMarkup returns a <error> markup showing the error, which is reported
+// to the closest error boundary once rendered.
+func errorMarkup(err error) *Markup {
+	em := NewMarkup("error", false)
+	em.textContentFn = func(owner *Markup) string {
+		return renderError(owner, err)
+	}
+
+	return em
+}
@@ -0,0 +1,90 @@
+package trees_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+type brokenMorpher struct{}
+
+func (brokenMorpher) Morph(m *trees.Markup) *trees.Markup {
+	panic(errors.New("morph failed"))
+}
+
+func TestErrorBoundary(t *testing.T) {
+	var reports []*trees.RenderError
+
+	page := trees.ParseTree(`<div id="page"><h1>Title</h1></div>`)[0]
+
+	widget := trees.NewMarkup("section", false)
+	trees.NewAttr("id", "widget").Apply(widget)
+	trees.NewErrorBoundary(func(rerr *trees.RenderError) *trees.Markup {
+		return trees.ParseTree(`<p class="fallback">Widget unavailable</p>`)[0]
+	}, func(rerr *trees.RenderError) {
+		reports = append(reports, rerr)
+	}).Apply(widget)
+	page.AddChild(widget)
+
+	broken := trees.NewContextText(func(owner *trees.Markup) string {
+		panic("bad text")
+	})
+
+	span := trees.NewMarkup("span", false)
+	span.AddChild(broken)
+	widget.AddChild(span)
+
+	html := page.HTML()
+	if !strings.Contains(html, "Widget unavailable") || !strings.Contains(html, "Title") {
+		t.Fatalf("\t%s\t  Should have rendered fallback in place of broken widget: %s", failed, html)
+	}
+
+	if len(reports) != 1 || reports[0].Node != broken || reports[0].Boundary != widget || reports[0].Err.Error() != "bad text" {
+		t.Fatalf("\t%s\t  Should have reported panic with failed markup: %#v", failed, reports)
+	}
+	t.Logf("\t%s\t  Should have caught panic while printing", success)
+
+	span.Empty()
+	span.AddMorpher(brokenMorpher{})
+	page.ApplyMorphers()
+
+	if widget.Failure() == nil || widget.Failure().Selector != span.IDSelector(false) || !strings.Contains(page.HTML(), "Widget unavailable") {
+		t.Fatalf("\t%s\t  Should have caught panic while morphing: %#v", failed, widget.Failure())
+	}
+	t.Logf("\t%s\t  Should have caught panic while morphing", success)
+
+	span.Empty()
+	page.ApplyMorphers()
+
+	sheet := trees.CSSStylesheet(`& { color: {{ .Missing.Color }}; }`, 20, nil, false)
+	widget.AddChild(sheet)
+
+	if html := page.HTML(); !strings.Contains(html, "Widget unavailable") || len(reports) != 3 {
+		t.Fatalf("\t%s\t  Should have caught stylesheet errors: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have caught stylesheet errors", success)
+
+	plain := trees.CSSStylesheet(`& { color: {{ .Missing.Color }}; }`, 20, nil, false)
+	if html := plain.HTML(); !strings.Contains(html, "Missing") {
+		t.Fatalf("\t%s\t  Should have kept error content without boundary: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have kept error content without boundary", success)
+
+	unguarded := trees.NewMarkup("div", false)
+	unguarded.AddChild(trees.NewContextText(func(owner *trees.Markup) string {
+		panic("bad text")
+	}))
+
+	func() {
+		defer func() {
+			if r := recover(); r != "bad text" {
+				t.Fatalf("\t%s\t  Should have kept panic without boundary as is: %#v", failed, r)
+			}
+		}()
+
+		unguarded.HTML()
+	}()
+	t.Logf("\t%s\t  Should have kept panic without boundary as is", success)
+}
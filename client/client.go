@@ -0,0 +1,197 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/notifications"
+)
+
+// payload defines the json payload sent by the runtime for an event.
+type payload struct {
+	EventName string          `json:"event"`
+	EventID   string          `json:"event_id"`
+	Type      string          `json:"type,omitempty"`
+	Target    string          `json:"target,omitempty"`
+	Event     json.RawMessage `json:"event_object"`
+}
+
+// Decode decodes the json payload sent by the runtime into a EventBroadcast,
+// with its event object decoded by trees.DecodeEvent.
+func Decode(data []byte) (trees.EventBroadcast, error) {
+	var pl payload
+	if err := json.Unmarshal(data, &pl); err != nil {
+		return trees.EventBroadcast{}, err
+	}
+
+	if pl.EventID == "" {
+		return trees.EventBroadcast{}, ErrNoEventID
+	}
+
+	ev, err := trees.DecodeEvent(pl.Event)
+	if err != nil {
+		return trees.EventBroadcast{}, err
+	}
+
+	return trees.EventBroadcast{EventName: pl.EventName, EventID: pl.EventID, Event: ev}, nil
+}
+
+// Encode encodes the EventBroadcast into the json payload sent by the
+// runtime.
+func Encode(evm trees.EventBroadcast) ([]byte, error) {
+	ev, err := json.Marshal(evm.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(payload{EventName: evm.EventName, EventID: evm.EventID, Event: ev})
+}
+
+// Deliver decodes the payload sent by the runtime and dispatches it with the
+// dispatcher, or with the global notifications dispatcher if nil.
+func Deliver(d *trees.Dispatcher, data []byte) error {
+	evm, err := Decode(data)
+	if err != nil {
+		return err
+	}
+
+	if d == nil {
+		notifications.Dispatch(evm)
+		return nil
+	}
+
+	d.Dispatch(evm)
+	return nil
+}
+
+//==============================================================================
+
+// Transport defines the channel through which the payloads of the runtime
+// reach the server.
+type Transport interface {
+	Send(payload []byte) error
+}
+
+// TransportFunc defines a function type which implements Transport.
+type TransportFunc func(payload []byte) error
+
+// Send calls the function with the payload.
+func (fn TransportFunc) Send(payload []byte) error {
+	return fn(payload)
+}
+
+// DispatchTransport returns a Transport delivering the payloads to the
+// dispatcher, or to the global notifications dispatcher if nil, as the server
+// does for payloads posted by the runtime.
+func DispatchTransport(d *trees.Dispatcher) Transport {
+	return TransportFunc(func(payload []byte) error {
+		return Deliver(d, payload)
+	})
+}
+
+// Client defines a Go counterpart of the runtime, delivering events fired on
+// markups to the bindings matching them, from the target outward, as the
+// runtime does in the browser, and sending their payloads through its
+// Transport. It allows testing the events of a tree without a browser.
+//
+// The Keys, Once, Throttle and Debounce options are not applied by the Client
+// as they are applied again on the Go side when the events are delivered.
+type Client struct {
+	transport Transport
+	bindings  []trees.EventJSON
+}
+
+// NewClient returns a new Client sending payloads through the transport.
+func NewClient(transport Transport) *Client {
+	return &Client{transport: transport}
+}
+
+// Bind adds the Bindings of the root, replacing bindings with the same event
+// id.
+func (c *Client) Bind(root *trees.Markup) {
+	for _, record := range Bindings(root) {
+		c.Unbind(record.EventID)
+		c.bindings = append(c.bindings, record)
+	}
+}
+
+// Unbind removes the bindings with the event id.
+func (c *Client) Unbind(eventID string) {
+	bindings := c.bindings[:0]
+
+	for _, record := range c.bindings {
+		if record.EventID != eventID {
+			bindings = append(bindings, record)
+		}
+	}
+
+	c.bindings = bindings
+}
+
+// Fire sends the payloads of the event of the giving type fired at the
+// target for all bindings matching the target and its parents, stopping at
+// the parents of bindings with StopPropagation. The "type", "target" and
+// "currentTarget" of the payloads are set as the runtime does. It returns the
+// number of payloads sent.
+func (c *Client) Fire(target *trees.Markup, eventType string, ev trees.EventObject) (int, error) {
+	var sent int
+
+	for node := target; node != nil; node = node.Parent() {
+		selector := node.IDSelector(false)
+		var stop bool
+
+		for _, record := range c.bindings {
+			if record.EventSelector != selector || !strings.EqualFold(record.Event, eventType) {
+				continue
+			}
+
+			obj, err := eventObject(ev, eventType, target.IDSelector(false), selector)
+			if err != nil {
+				return sent, err
+			}
+
+			data, err := json.Marshal(payload{EventName: record.EventName, EventID: record.EventID, Type: strings.ToLower(eventType), Target: node.UID(), Event: obj})
+			if err != nil {
+				return sent, err
+			}
+
+			if err := c.transport.Send(data); err != nil {
+				return sent, err
+			}
+
+			sent++
+			stop = stop || record.StopPropagation || record.StopImmediatePropagation
+		}
+
+		if stop {
+			break
+		}
+	}
+
+	return sent, nil
+}
+
+// eventObject returns the json of the event object with its "target" and
+// "currentTarget" fields set, and its "type" if empty, when it is a json
+// object.
+func eventObject(ev trees.EventObject, eventType string, target, current string) (json.RawMessage, error) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return data, nil
+	}
+
+	if kind, ok := fields["type"]; !ok || string(kind) == `""` {
+		fields["type"], _ = json.Marshal(strings.ToLower(eventType))
+	}
+
+	fields["target"], _ = json.Marshal(target)
+	fields["currentTarget"], _ = json.Marshal(current)
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,310 @@
+package client_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/client"
+	"github.com/gu-io/trees/events"
+	"github.com/influx6/faux/tests"
+)
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	client.NewHandler("/live/events").ServeHTTP(rec, httptest.NewRequest("GET", "/trees.js", nil))
+
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/javascript") {
+		tests.Failed("Should have served runtime script: %d", rec.Code)
+	}
+	tests.Passed("Should have served runtime script")
+
+	if body := rec.Body.String(); !strings.Contains(body, `window.TreesConfig = {"endpoint":"/live/events"}`) || !strings.Contains(body, "data-trees-events") {
+		tests.Failed("Should have configured runtime with endpoint")
+	}
+	tests.Passed("Should have configured runtime with endpoint")
+
+	rec = httptest.NewRecorder()
+	client.NewHandler("").ServeHTTP(rec, httptest.NewRequest("POST", "/trees.js", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		tests.Failed("Should have rejected post requests: %d", rec.Code)
+	}
+	tests.Passed("Should have rejected post requests")
+}
+
+func TestRuntimePayloadFields(t *testing.T) {
+	runtime := client.NewHandler("").Script()
+
+	for _, ev := range []interface{}{trees.MouseEvent{}, trees.KeyboardEvent{}, trees.InputEvent{}, trees.WheelEvent{}, trees.Touch{}} {
+		data, _ := json.Marshal(ev)
+
+		var fields map[string]interface{}
+		json.Unmarshal(data, &fields)
+
+		for name := range fields {
+			if !strings.Contains(runtime, `"`+name+`"`) && !strings.Contains(runtime, "out."+name) {
+				tests.Failed("Should have serialized field %q of %T in runtime", name, ev)
+			}
+		}
+	}
+	tests.Passed("Should have serialized all payload fields in runtime")
+}
+
+func TestClient(t *testing.T) {
+	dispatcher := trees.NewDispatcher()
+
+	form := trees.NewMarkup("form", false)
+	dispatcher.Apply(form)
+	defer form.Empty()
+
+	button := trees.NewMarkup("button", false)
+	button.Apply(form)
+
+	var clicked *trees.MouseEvent
+	var delegated string
+
+	events.ClickEvent(func(ev *trees.MouseEvent) {
+		clicked = ev
+	}).Apply(button)
+
+	events.ClickEvent(func(ev *trees.MouseEvent, current *trees.Markup) {
+		delegated = ev.CurrentTarget
+	}).Apply(form)
+
+	script := client.Script(form)
+	if !strings.Contains(script.HTML(), button.Events()[0].ID()) {
+		tests.Failed("Should have rendered bindings of tree in script")
+	}
+	tests.Passed("Should have rendered bindings of tree in script")
+
+	var payloads []string
+
+	c := client.NewClient(client.TransportFunc(func(payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return client.Deliver(dispatcher, payload)
+	}))
+	c.Bind(form)
+
+	sent, err := c.Fire(button, "click", &trees.MouseEvent{ClientX: 10})
+	if err != nil || sent != 2 {
+		tests.Failed("Should have sent payloads for target and delegated bindings: %d, %+q", sent, err)
+	}
+	tests.Passed("Should have sent payloads for target and delegated bindings")
+
+	if clicked == nil || clicked.ClientX != 10 || clicked.Target != button.IDSelector(false) {
+		tests.Failed("Should have delivered decoded event to handler: %s", payloads[0])
+	}
+	tests.Passed("Should have delivered decoded event to handler")
+
+	if delegated != form.IDSelector(false) {
+		tests.Failed("Should have set current target of delegated event: %q", delegated)
+	}
+	tests.Passed("Should have set current target of delegated event")
+
+	if _, err := client.Decode([]byte(`{"event":"ClickEvent","event_object":{}}`)); err != client.ErrNoEventID {
+		tests.Failed("Should have rejected payload without event id: %+q", err)
+	}
+	tests.Passed("Should have rejected payload without event id")
+}
+
+func TestRuntime(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is required to run the runtime")
+	}
+
+	dispatcher := trees.NewDispatcher()
+
+	form := trees.NewMarkup("form", false)
+	dispatcher.Apply(form)
+	defer form.Empty()
+
+	input := trees.NewMarkup("input", false)
+	input.Apply(form)
+
+	button := trees.NewMarkup("button", false)
+	button.Apply(form)
+
+	var clicked *trees.MouseEvent
+	var clicks, delegated, saves int
+
+	events.ClickEvent(func(ev *trees.MouseEvent) {
+		clicked = ev
+		clicks++
+	}, trees.StopPropagation(true), trees.Once()).Apply(button)
+
+	events.ClickEvent(func() { delegated++ }).Apply(form)
+
+	events.KeyDownEvent(func(ev *trees.KeyboardEvent) {
+		saves++
+	}, trees.Keys("ctrl+s"), trees.PreventDefault(true)).Apply(input)
+
+	events.InputEvent(func() {}, trees.Debounce(20*time.Millisecond)).Apply(input)
+	events.KeyUpEvent(func() {}, trees.Throttle(time.Hour)).Apply(input)
+
+	fires := []domFire{
+		{Type: "click", Target: button.UID(), Event: &trees.MouseEvent{ClientX: 10}},
+		{Type: "click", Target: button.UID(), Event: &trees.MouseEvent{}},
+		{Type: "keydown", Target: input.UID(), Event: &trees.KeyboardEvent{Key: "s", ModifierKeys: trees.ModifierKeys{CtrlKey: true}}},
+		{Type: "keydown", Target: input.UID(), Event: &trees.KeyboardEvent{Key: "a"}},
+		{Type: "input", Target: input.UID(), Event: &trees.InputEvent{}},
+		{Type: "input", Target: input.UID(), Event: &trees.InputEvent{}},
+		{Type: "input", Target: input.UID(), Event: &trees.InputEvent{}, Wait: 100},
+		{Type: "keyup", Target: input.UID(), Event: &trees.KeyboardEvent{Key: "a"}},
+		{Type: "keyup", Target: input.UID(), Event: &trees.KeyboardEvent{Key: "a"}},
+	}
+
+	results := runRuntime(node, form, fires)
+
+	var sent []int
+	for _, result := range results {
+		sent = append(sent, len(result.Payloads))
+
+		for _, payload := range result.Payloads {
+			if err := client.Deliver(dispatcher, payload); err != nil {
+				tests.Failed("Should have delivered runtime payload: %+q", err)
+			}
+		}
+	}
+
+	if got := fmt.Sprint(sent); got != "[1 0 1 0 0 0 1 1 0]" {
+		tests.Failed("Should have applied propagation, once, keys, debounce and throttle in runtime: %s", got)
+	}
+	tests.Passed("Should have applied propagation, once, keys, debounce and throttle in runtime")
+
+	if !results[2].Prevented || results[3].Prevented {
+		tests.Failed("Should have prevented default action of matching bindings only")
+	}
+	tests.Passed("Should have prevented default action of matching bindings only")
+
+	if clicks != 1 || delegated != 0 || saves != 1 || clicked.ClientX != 10 || clicked.Target != button.IDSelector(false) || clicked.CurrentTarget != button.IDSelector(false) {
+		tests.Failed("Should have delivered runtime payloads to handlers: %d, %d, %d", clicks, delegated, saves)
+	}
+	tests.Passed("Should have delivered runtime payloads to handlers")
+
+	var payloads [][]byte
+	c := client.NewClient(client.TransportFunc(func(payload []byte) error {
+		payloads = append(payloads, payload)
+		return nil
+	}))
+	c.Bind(form)
+
+	if _, err := c.Fire(button, "click", &trees.MouseEvent{ClientX: 10}); err != nil || len(payloads) != 1 {
+		tests.Failed("Should have sent payload from client: %+q", err)
+	}
+
+	if want, got := payloadSummary(results[0].Payloads[0]), payloadSummary(payloads[0]); want != got {
+		tests.Failed("Should have sent same payload as runtime: %s != %s", got, want)
+	}
+	tests.Passed("Should have sent same payload as runtime")
+}
+
+func TestRuntimeSync(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is required to run the runtime")
+	}
+
+	button := trees.NewMarkup("button", false)
+	events.ClickEvent(func() {}, trees.Once()).Apply(button)
+	defer button.Release()
+
+	bindings := client.Bindings(button)
+	click := func(sync []trees.EventJSON) domFire {
+		fire := domFire{Type: "click", Target: button.UID(), Event: &trees.MouseEvent{}}
+		if sync != nil {
+			fire.Sync = sync
+		}
+
+		return fire
+	}
+
+	results := runRuntime(node, button, []domFire{click(nil), click(bindings), click([]trees.EventJSON{}), click(bindings)})
+
+	var sent []int
+	for _, result := range results {
+		sent = append(sent, len(result.Payloads))
+	}
+
+	if got := fmt.Sprint(sent); got != "[1 0 0 1]" {
+		tests.Failed("Should have kept limits of bindings still synced only: %s", got)
+	}
+	tests.Passed("Should have kept limits of bindings still synced only")
+}
+
+// domFire defines a event fired by testdata/dom.js at the markup with the
+// target uid, after replacing the bindings with Sync if set, waiting Wait
+// milliseconds for the payloads sent after it.
+type domFire struct {
+	Type   string            `json:"type"`
+	Target string            `json:"target"`
+	Event  trees.EventObject `json:"event"`
+	Sync   interface{}       `json:"sync,omitempty"`
+	Wait   int               `json:"wait,omitempty"`
+}
+
+// domResult defines the payloads sent by the runtime for a fired event.
+type domResult struct {
+	Payloads  []json.RawMessage `json:"payloads"`
+	Prevented bool              `json:"prevented"`
+}
+
+// runRuntime runs the runtime with node against the markup and its bindings,
+// returning the results of the fired events.
+func runRuntime(node string, root *trees.Markup, fires []domFire) []domResult {
+	data, _ := json.Marshal(map[string]interface{}{"tree": domNode(root), "bindings": client.Bindings(root), "fire": fires})
+
+	cmd := exec.Command(node, "testdata/dom.js", "runtime.js")
+	cmd.Stdin = bytes.NewReader(data)
+
+	out, err := cmd.Output()
+	if err != nil {
+		tests.Failed("Should have run runtime with node: %+q", err)
+	}
+
+	var results []domResult
+	if err := json.Unmarshal(out, &results); err != nil || len(results) != len(fires) {
+		tests.Failed("Should have received payloads for each fired event: %s", out)
+	}
+	tests.Passed("Should have run runtime with node")
+
+	return results
+}
+
+// domNode returns the json of the markup used by testdata/dom.js to build
+// its elements.
+func domNode(m *trees.Markup) map[string]interface{} {
+	var children []interface{}
+	for _, child := range m.Children() {
+		children = append(children, domNode(child))
+	}
+
+	return map[string]interface{}{"tag": m.Name(), "id": m.ID, "uid": m.UID(), "children": children}
+}
+
+// payloadSummary returns the fields of the payload set by the runtime.
+func payloadSummary(data []byte) string {
+	var payload struct {
+		Event     string `json:"event"`
+		EventID   string `json:"event_id"`
+		Type      string `json:"type"`
+		Target    string `json:"target"`
+		EventData struct {
+			Type          string  `json:"type"`
+			Target        string  `json:"target"`
+			CurrentTarget string  `json:"currentTarget"`
+			ClientX       float64 `json:"clientX"`
+		} `json:"event_object"`
+	}
+
+	json.Unmarshal(data, &payload)
+	return fmt.Sprintf("%+v", payload)
+}
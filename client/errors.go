@@ -0,0 +1,6 @@
+package client
+
+import "errors"
+
+// ErrNoEventID is returned when a payload sent by the runtime has no event id.
+var ErrNoEventID = errors.New("Payload has no event id")
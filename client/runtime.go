@@ -0,0 +1,96 @@
+// Package client provides the javascript runtime which attaches the events of
+// markups rendered by the server in the browser and sends the events back,
+// along with the Go side pieces decoding and delivering them to dispatchers.
+package client
+
+import (
+	// embeds the runtime script.
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gu-io/trees"
+)
+
+// runtime contains the javascript runtime.
+//
+//go:embed runtime.js
+var runtime string
+
+// DefaultEndpoint defines the endpoint the runtime posts events to when none
+// is configured.
+const DefaultEndpoint = "/events"
+
+// Handler defines a http.Handler serving the javascript runtime, configured to
+// post events to its endpoint, e.g served by a events.IngestHandler.
+type Handler struct {
+	Endpoint string
+}
+
+// NewHandler returns a new Handler for the endpoint, using DefaultEndpoint if
+// empty.
+func NewHandler(endpoint string) *Handler {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	return &Handler{Endpoint: endpoint}
+}
+
+// Script returns the runtime script with its configuration.
+func (h *Handler) Script() string {
+	config, _ := json.Marshal(map[string]string{"endpoint": h.Endpoint})
+	return fmt.Sprintf("window.TreesConfig = %s;\n%s", config, runtime)
+}
+
+// ServeHTTP serves the runtime script.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	fmt.Fprint(w, h.Script())
+}
+
+// Bindings returns the EventJSON of all the events of the root and its
+// descendants with handlers, which are the events the runtime listens for.
+func Bindings(root *trees.Markup) []trees.EventJSON {
+	var records []trees.EventJSON
+
+	root.EachEvent(func(ev *trees.Event, _ *trees.Markup) {
+		if ev.Handler != nil {
+			records = append(records, ev.EventJSON())
+		}
+	})
+
+	return records
+}
+
+// Script returns a <script type="application/json" data-trees-events> markup
+// containing the Bindings of the root, loaded by the runtime once the page is
+// ready.
+func Script(root *trees.Markup) *trees.Markup {
+	records := Bindings(root)
+	if records == nil {
+		records = []trees.EventJSON{}
+	}
+
+	data, _ := json.Marshal(records)
+
+	script := trees.NewMarkup("script", false)
+	trees.NewAttr("type", "application/json").Apply(script)
+	trees.NewAttr("data-trees-events", root.UID()).Apply(script)
+	trees.NewText("%s", data).Apply(script)
+
+	return script
+}
@@ -0,0 +1,338 @@
+// The trees client runtime attaches delegated listeners for the events
+// declared by the server and posts each event back as a json payload
+// ({"event", "event_id", "type", "target", "event_object"}), the target being
+// the uid of the markup of the event.
+(function (window, document) {
+	"use strict";
+
+	var config = window.TreesConfig || {};
+	var bindings = {};
+	var listening = {};
+	var limits = {};
+
+	var baseFields = ["type", "timeStamp", "bubbles", "cancelable", "defaultPrevented", "isTrusted"];
+	var modifierFields = ["altKey", "ctrlKey", "shiftKey", "metaKey"];
+	var mouseFields = ["detail", "button", "buttons", "screenX", "screenY", "clientX", "clientY", "pageX", "pageY", "offsetX", "offsetY", "movementX", "movementY"];
+	var keyboardFields = ["key", "code", "location", "repeat", "isComposing"];
+	var inputFields = ["data", "inputType", "isComposing"];
+	var wheelFields = ["deltaX", "deltaY", "deltaZ", "deltaMode"];
+	var touchFields = ["identifier", "screenX", "screenY", "clientX", "clientY", "pageX", "pageY", "radiusX", "radiusY", "rotationAngle", "force"];
+
+	// selector returns the selector the server uses for the element.
+	function selector(el) {
+		if (!el || el.nodeType !== 1) {
+			return "";
+		}
+
+		if (el.id) {
+			return "#" + el.id;
+		}
+
+		var uid = el.getAttribute("uid");
+		if (uid) {
+			return el.tagName.toLowerCase() + "[uid='" + uid + "']";
+		}
+
+		return el.tagName.toLowerCase();
+	}
+
+	function copy(target, source, fields) {
+		for (var i = 0; i < fields.length; i++) {
+			if (source[fields[i]] !== undefined) {
+				target[fields[i]] = source[fields[i]];
+			}
+		}
+
+		return target;
+	}
+
+	function touches(list) {
+		var out = [];
+
+		for (var i = 0; list && i < list.length; i++) {
+			var touch = copy({}, list[i], touchFields);
+			touch.target = selector(list[i].target);
+			out.push(touch);
+		}
+
+		return out;
+	}
+
+	// serialize turns the DOM event into the payload decoded by
+	// trees.DecodeEvent.
+	function serialize(ev, current) {
+		var out = copy({}, ev, baseFields);
+		out.target = selector(ev.target);
+		out.currentTarget = selector(current);
+
+		copy(out, ev, modifierFields);
+		copy(out, ev, mouseFields);
+		copy(out, ev, keyboardFields);
+		copy(out, ev, inputFields);
+		copy(out, ev, wheelFields);
+
+		if (ev.relatedTarget !== undefined) {
+			out.relatedTarget = selector(ev.relatedTarget);
+		}
+
+		var target = ev.target;
+		if (target && target.value !== undefined) {
+			out.value = String(target.value);
+		}
+
+		if (target && target.checked !== undefined) {
+			out.checked = !!target.checked;
+		}
+
+		if (ev.touches) {
+			out.touches = touches(ev.touches);
+			out.targetTouches = touches(ev.targetTouches);
+			out.changedTouches = touches(ev.changedTouches);
+		}
+
+		if (ev.dataTransfer) {
+			var dt = ev.dataTransfer;
+			out.dataTransfer = {
+				dropEffect: dt.dropEffect,
+				effectAllowed: dt.effectAllowed,
+				types: Array.prototype.slice.call(dt.types || []),
+				files: Array.prototype.map.call(dt.files || [], function (file) { return file.name; }),
+				data: {}
+			};
+
+			for (var i = 0; i < out.dataTransfer.types.length; i++) {
+				var kind = out.dataTransfer.types[i];
+				if (kind !== "Files") {
+					out.dataTransfer.data[kind] = dt.getData(kind);
+				}
+			}
+		}
+
+		if (ev.type === "submit" && target && target.elements) {
+			out.submitter = selector(ev.submitter);
+			out.values = {};
+
+			for (var j = 0; j < target.elements.length; j++) {
+				var field = target.elements[j];
+				if (!field.name || field.disabled || ((field.type === "checkbox" || field.type === "radio") && !field.checked)) {
+					continue;
+				}
+
+				(out.values[field.name] = out.values[field.name] || []).push(String(field.value));
+			}
+		}
+
+		return out;
+	}
+
+	// matchKeys returns true if the keyboard event matches one of the keys of
+	// the binding, using the rules of trees.Keys.
+	function matchKeys(binding, ev) {
+		if (!binding.Keys || !binding.Keys.length || ev.key === undefined) {
+			return true;
+		}
+
+		for (var i = 0; i < binding.Keys.length; i++) {
+			var parts = binding.Keys[i].split("+");
+			var key = parts.pop();
+			if (key === "" && parts.length) {
+				key = "+";
+				parts.pop();
+			}
+
+			var mods = { ctrl: false, alt: false, shift: false, meta: false };
+			var known = true;
+
+			for (var j = 0; j < parts.length; j++) {
+				var mod = parts[j].trim().toLowerCase();
+				mod = { control: "ctrl", option: "alt", cmd: "meta", command: "meta" }[mod] || mod;
+
+				if (!(mod in mods)) {
+					known = false;
+				}
+
+				mods[mod] = true;
+			}
+
+			if (known && mods.ctrl === ev.ctrlKey && mods.alt === ev.altKey && mods.shift === ev.shiftKey &&
+				mods.meta === ev.metaKey && key.toLowerCase() === String(ev.key).toLowerCase()) {
+				return true;
+			}
+		}
+
+		return false;
+	}
+
+	// limit calls the function if allowed by the once, throttle and debounce
+	// options of the binding, which the server applies again on delivery.
+	function limit(binding, fn) {
+		var state = limits[binding.EventID] = limits[binding.EventID] || {};
+		if (state.done) {
+			return;
+		}
+
+		if (binding.Throttle > 0) {
+			var now = Date.now();
+			if (state.last && now - state.last < binding.Throttle) {
+				return;
+			}
+
+			state.last = now;
+		}
+
+		var run = function () {
+			state.timer = null;
+			state.done = !!binding.Once;
+			fn();
+		};
+
+		if (binding.Debounce > 0) {
+			clearTimeout(state.timer);
+			state.timer = setTimeout(run, binding.Debounce);
+			return;
+		}
+
+		run();
+	}
+
+	// forget drops the limits of the event id, with its pending debounced
+	// payload.
+	function forget(eventID) {
+		if (limits[eventID]) {
+			clearTimeout(limits[eventID].timer);
+			delete limits[eventID];
+		}
+	}
+
+	// remove removes the records with the event id, keeping its limits.
+	function remove(eventID) {
+		for (var type in bindings) {
+			bindings[type] = bindings[type].filter(function (record) {
+				return record.EventID !== eventID;
+			});
+		}
+	}
+
+	// handle delivers the DOM event to the bindings of its type whose element
+	// contains the target, from the closest one outward.
+	function handle(ev) {
+		var list = bindings[ev.type] || [];
+
+		for (var el = ev.target; el && el.nodeType === 1; el = el.parentNode) {
+			var stop = false;
+
+			for (var i = 0; i < list.length; i++) {
+				var binding = list[i];
+				if (!el.matches(binding.EventSelector) || !matchKeys(binding, ev)) {
+					continue;
+				}
+
+				if (binding.PreventDefault && !binding.Passive) {
+					ev.preventDefault();
+				}
+
+				var payload = {
+					event: binding.EventName,
+					event_id: binding.EventID,
+					type: ev.type,
+					target: el.getAttribute("uid") || "",
+					event_object: serialize(ev, el)
+				};
+
+				limit(binding, function (payload) {
+					return function () { trees.send(payload); };
+				}(payload));
+
+				stop = stop || binding.StopPropagation || binding.StopImmediatePropagation;
+			}
+
+			if (stop) {
+				return;
+			}
+		}
+	}
+
+	var trees = window.trees = {
+		endpoint: config.endpoint || "/events",
+
+		// transport sends the payload to the server, replaceable to use other
+		// transports than http.
+		transport: function (payload) {
+			return window.fetch(trees.endpoint, {
+				method: "POST",
+				headers: { "Content-Type": "application/json" },
+				body: JSON.stringify(payload),
+				keepalive: true
+			});
+		},
+
+		send: function (payload) {
+			return trees.transport(payload);
+		},
+
+		// bind adds the EventJSON records, replacing records with the same
+		// event id.
+		bind: function (records) {
+			for (var i = 0; records && i < records.length; i++) {
+				var record = records[i];
+				var type = String(record.Event).toLowerCase();
+
+				remove(record.EventID);
+				(bindings[type] = bindings[type] || []).push(record);
+
+				if (!listening[type]) {
+					listening[type] = true;
+					document.addEventListener(type, handle, { capture: true });
+				}
+			}
+		},
+
+		// unbind removes the records with the event id.
+		unbind: function (eventID) {
+			remove(eventID);
+			forget(eventID);
+		},
+
+		// sync replaces all records with the records, keeping the limits of
+		// the event ids which are still bound.
+		sync: function (records) {
+			var ids = {};
+			for (var i = 0; records && i < records.length; i++) {
+				ids[records[i].EventID] = true;
+			}
+
+			for (var id in limits) {
+				if (!ids[id]) {
+					forget(id);
+				}
+			}
+
+			bindings = {};
+			trees.bind(records);
+		},
+
+		// clear removes all records, keeping the document listeners which
+		// ignore types without records.
+		clear: function () {
+			for (var id in limits) {
+				forget(id);
+			}
+
+			bindings = {};
+		},
+
+		// load binds the records of all <script data-trees-events> elements.
+		load: function () {
+			var scripts = document.querySelectorAll("script[data-trees-events]");
+			for (var i = 0; i < scripts.length; i++) {
+				trees.bind(JSON.parse(scripts[i].textContent || "[]"));
+			}
+		}
+	};
+
+	if (document.readyState === "loading") {
+		document.addEventListener("DOMContentLoaded", trees.load);
+	} else {
+		trees.load();
+	}
+})(window, document);
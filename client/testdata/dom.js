@@ -0,0 +1,108 @@
+// dom.js runs the client runtime given as first argument against a minimal
+// DOM built from the json read on stdin ({"tree", "bindings", "fire"}),
+// writing the payloads sent for each fired event as json on stdout. Fired
+// events with "sync" records replace the bindings with them first, and those
+// with a "wait" in milliseconds also get the payloads sent during the wait.
+"use strict";
+
+var fs = require("fs");
+var vm = require("vm");
+
+var input = JSON.parse(fs.readFileSync(0, "utf8"));
+var runtime = fs.readFileSync(process.argv[2], "utf8");
+
+var byUID = {};
+
+function Element(node, parent) {
+	this.nodeType = 1;
+	this.tagName = node.tag.toUpperCase();
+	this.id = node.id || "";
+	this.uid = node.uid;
+	this.parentNode = parent;
+	this.children = (node.children || []).map(function (child) {
+		return new Element(child, this);
+	}, this);
+
+	byUID[node.uid] = this;
+}
+
+Element.prototype.getAttribute = function (name) {
+	return name === "uid" ? this.uid : null;
+};
+
+// matches supports the selectors rendered by Markup.IDSelector.
+Element.prototype.matches = function (selector) {
+	var match;
+
+	if ((match = /^#(.+)$/.exec(selector))) {
+		return this.id === match[1];
+	}
+
+	if ((match = /^([\w-]+)\[uid='([^']*)'\]$/.exec(selector))) {
+		return this.tagName.toLowerCase() === match[1] && this.uid === match[2];
+	}
+
+	return this.tagName.toLowerCase() === selector;
+};
+
+new Element(input.tree, null);
+
+var listeners = {};
+
+var document = {
+	readyState: "complete",
+	addEventListener: function (type, fn) {
+		(listeners[type] = listeners[type] || []).push(fn);
+	},
+	querySelectorAll: function () {
+		return [{ textContent: JSON.stringify(input.bindings) }];
+	}
+};
+
+var window = { TreesConfig: { endpoint: "/events" } };
+
+vm.runInNewContext(runtime, { window: window, document: document, Date: Date, setTimeout: setTimeout, clearTimeout: clearTimeout });
+
+var sent = [];
+window.trees.transport = function (payload) {
+	sent.push(payload);
+};
+
+var results = [];
+
+function step(index) {
+	if (index === input.fire.length) {
+		process.stdout.write(JSON.stringify(results));
+
+		// pending debounced payloads must not keep the process running.
+		process.exit(0);
+		return;
+	}
+
+	var fire = input.fire[index];
+	sent = [];
+
+	if (fire.sync) {
+		window.trees.sync(fire.sync);
+	}
+
+	var ev = Object.assign({}, fire.event, {
+		type: fire.type,
+		target: byUID[fire.target],
+		defaultPrevented: false,
+		preventDefault: function () {
+			this.defaultPrevented = true;
+		}
+	});
+
+	(listeners[fire.type] || []).forEach(function (fn) {
+		fn(ev);
+	});
+
+	setTimeout(function () {
+		results.push({ payloads: sent, prevented: ev.defaultPrevented });
+		step(index + 1);
+	}, fire.wait || 0);
+}
+
+step(0);
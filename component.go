@@ -0,0 +1,204 @@
+package trees
+
+// Component defines a type which renders its view as a markup.
+type Component interface {
+	Render() *Markup
+}
+
+// Mountable defines a component which is notified after its first render was
+// mounted.
+type Mountable interface {
+	Mounted()
+}
+
+// Updatable defines a component which is notified after a re-render was
+// reconciled and mounted.
+type Updatable interface {
+	Updated()
+}
+
+// Unmountable defines a component which is notified after it was unmounted.
+type Unmountable interface {
+	Unmounted()
+}
+
+// Updater defines a component which decides if it should be re-rendered,
+// receiving its previous render.
+type Updater interface {
+	ShouldUpdate(prev *Markup) bool
+}
+
+// Mount defines a mount root for a component, which manages the rendering,
+// reconciliation and lifecycle hooks of the component.
+//
+// A component's first render is followed by its Mounted hook. Each Update
+// asks ShouldUpdate with the previous render, re-renders the component,
+// reconciles the new render against the previous one, replaces the previous
+// render within its parent and calls the Updated hook. Unmount removes the
+// render from its parent and calls the Unmounted hook.
+//
+// Components rendering other components should keep their Mount and apply
+// it within their Render, which mounts it on first use and updates it on all
+// later uses, calling the hooks of the inner component before the outer one.
+type Mount struct {
+	component Component
+	current   *Markup
+	mounted   bool
+	renders   int
+}
+
+// NewMount returns a new Mount for the component.
+func NewMount(c Component) *Mount {
+	return &Mount{component: c}
+}
+
+// Component returns the component of the mount.
+func (m *Mount) Component() Component {
+	return m.component
+}
+
+// Markup returns the current render of the component, which is nil if not
+// mounted.
+func (m *Mount) Markup() *Markup {
+	return m.current
+}
+
+// Mounted returns true/false if the component is mounted.
+func (m *Mount) Mounted() bool {
+	return m.mounted
+}
+
+// Apply mounts the component's render as a child of the giving markup if not
+// mounted, else it updates the component and moves the current render into
+// the giving markup.
+func (m *Mount) Apply(parent *Markup) {
+	if !m.mounted {
+		m.mount()
+		parent.AddChild(m.current)
+		m.hook()
+		return
+	}
+
+	if m.current.parent == parent {
+		m.Update()
+		return
+	}
+
+	// the previous render is left within its parent, which is mostly the
+	// previous render of an outer component being reconciled against, while
+	// an unchanged render is moved out of it.
+	prev := m.current
+	m.update(false)
+
+	if m.current == prev && prev.parent != nil {
+		prev.parent.removeChild(-1, prev)
+	}
+
+	parent.AddChild(m.current)
+}
+
+// Render mounts the component if not mounted or updates it, returning its
+// current render.
+func (m *Mount) Render() *Markup {
+	if !m.mounted {
+		m.mount()
+		m.hook()
+		return m.current
+	}
+
+	m.Update()
+	return m.current
+}
+
+// Update re-renders a mounted component, returning true/false if the render
+// changed.
+func (m *Mount) Update() bool {
+	return m.update(true)
+}
+
+// update re-renders a mounted component, replacing the previous render within
+// its parent if replace is true.
+func (m *Mount) update(replace bool) bool {
+	if !m.mounted {
+		return false
+	}
+
+	prev := m.current
+
+	if updater, ok := m.component.(Updater); ok && !updater.ShouldUpdate(prev) {
+		return false
+	}
+
+	next := m.component.Render()
+	m.renders++
+
+	if next == nil || next == prev {
+		return false
+	}
+
+	changed := next.Reconcile(prev)
+
+	// the previous render is replaced, its events must stop firing.
+	prev.unsubscribeEvents(true)
+	next.subscribeEvents()
+
+	if parent := prev.parent; replace && parent != nil {
+		index := -1
+
+		for ind, child := range parent.children {
+			if child == prev {
+				index = ind
+				break
+			}
+		}
+
+		parent.removeChild(index, prev)
+		parent.insertChild(index, next)
+	}
+
+	m.current = next
+
+	if updatable, ok := m.component.(Updatable); ok {
+		updatable.Updated()
+	}
+
+	return changed
+}
+
+// Unmount removes the component's render from its parent and calls the
+// Unmounted hook of the component.
+func (m *Mount) Unmount() {
+	if !m.mounted {
+		return
+	}
+
+	if parent := m.current.parent; parent != nil {
+		parent.removeChild(-1, m.current)
+	}
+
+	m.current = nil
+	m.mounted = false
+
+	if unmountable, ok := m.component.(Unmountable); ok {
+		unmountable.Unmounted()
+	}
+}
+
+// mount renders the component for the first time.
+func (m *Mount) mount() {
+	m.current = m.component.Render()
+	m.renders++
+
+	if m.current == nil {
+		m.current = NewMarkup("div", false)
+	}
+
+	m.mounted = true
+}
+
+// hook calls the Mounted hook of the component.
+func (m *Mount) hook() {
+	if mountable, ok := m.component.(Mountable); ok {
+		mountable.Mounted()
+	}
+}
@@ -0,0 +1,104 @@
+package trees_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+type counter struct {
+	count  int
+	frozen bool
+	hooks  []string
+}
+
+func (c *counter) Render() *trees.Markup {
+	return trees.ParseTree(`<span class="count">` + strconv.Itoa(c.count) + `</span>`)[0]
+}
+
+func (c *counter) ShouldUpdate(prev *trees.Markup) bool {
+	return !c.frozen
+}
+
+func (c *counter) Mounted()   { c.hooks = append(c.hooks, "mounted") }
+func (c *counter) Updated()   { c.hooks = append(c.hooks, "updated") }
+func (c *counter) Unmounted() { c.hooks = append(c.hooks, "unmounted") }
+
+func TestComponentMount(t *testing.T) {
+	page := trees.NewMarkup("div", false)
+	comp := &counter{}
+	mount := trees.NewMount(comp)
+
+	mount.Apply(page)
+	first := mount.Markup()
+
+	if !mount.Mounted() || len(page.Children()) != 1 || page.Children()[0] != first {
+		t.Fatalf("\t%s\t  Should have mounted component into markup", failed)
+	}
+	t.Logf("\t%s\t  Should have mounted component into markup", success)
+
+	comp.count++
+	if !mount.Update() {
+		t.Fatalf("\t%s\t  Should have updated component render", failed)
+	}
+
+	second := mount.Markup()
+	if second == first || second.UID() != first.UID() || page.Children()[0] != second || len(page.Children()) != 1 {
+		t.Fatalf("\t%s\t  Should have reconciled and replaced previous render", failed)
+	}
+	t.Logf("\t%s\t  Should have reconciled and replaced previous render", success)
+
+	comp.frozen = true
+	comp.count++
+	if mount.Update() || mount.Markup() != second {
+		t.Fatalf("\t%s\t  Should have skipped update when ShouldUpdate returns false", failed)
+	}
+	t.Logf("\t%s\t  Should have skipped update when ShouldUpdate returns false", success)
+
+	mount.Unmount()
+	if mount.Mounted() || len(page.Children()) != 0 {
+		t.Fatalf("\t%s\t  Should have unmounted component", failed)
+	}
+
+	hooks := []string{"mounted", "updated", "unmounted"}
+	if len(comp.hooks) != len(hooks) {
+		t.Fatalf("\t%s\t  Should have called hooks %v: %v", failed, hooks, comp.hooks)
+	}
+
+	for index, hook := range hooks {
+		if comp.hooks[index] != hook {
+			t.Fatalf("\t%s\t  Should have called hooks %v: %v", failed, hooks, comp.hooks)
+		}
+	}
+	t.Logf("\t%s\t  Should have called lifecycle hooks in order", success)
+}
+
+type frame struct {
+	inner *trees.Mount
+}
+
+func (f *frame) Render() *trees.Markup {
+	root := trees.NewMarkup("article", false)
+	f.inner.Apply(root)
+	return root
+}
+
+func TestComponentMountMovesUnchangedRender(t *testing.T) {
+	inner := &counter{frozen: true}
+	outer := &frame{inner: trees.NewMount(inner)}
+
+	page := trees.NewMarkup("div", false)
+	mount := trees.NewMount(outer)
+	mount.Apply(page)
+
+	first := mount.Markup()
+	render := outer.inner.Markup()
+
+	mount.Update()
+
+	if outer.inner.Markup() != render || render.Parent() != mount.Markup() || len(first.Children()) != 0 {
+		t.Fatalf("\t%s\t  Should have moved unchanged render out of previous parent: %d", failed, len(first.Children()))
+	}
+	t.Logf("\t%s\t  Should have moved unchanged render out of previous parent", success)
+}
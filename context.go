@@ -0,0 +1,84 @@
+package trees
+
+// Provide sets the context value for the key on the markup, making it
+// available to the markup and all its descendants through Context.
+func (e *Markup) Provide(key interface{}, value interface{}) {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
+	if e.context == nil {
+		e.context = make(map[interface{}]interface{})
+	}
+
+	e.context[key] = value
+}
+
+// Context returns the context value for the key provided by the closest
+// markup in the parent chain, starting with the markup itself.
+func (e *Markup) Context(key interface{}) (interface{}, bool) {
+	for node := e; node != nil; node = node.parent {
+		if value, ok := node.context[key]; ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// ContextValue returns the context value for the key as done by Context,
+// returning nil if not found.
+func (e *Markup) ContextValue(key interface{}) interface{} {
+	value, _ := e.Context(key)
+	return value
+}
+
+// ContextProvider defines a Appliable which provides a context value to the
+// markup it is applied to.
+type ContextProvider struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// WithContext returns a new ContextProvider for the key and value.
+func WithContext(key interface{}, value interface{}) *ContextProvider {
+	return &ContextProvider{Key: key, Value: value}
+}
+
+// Apply provides the context value to the markup.
+func (c *ContextProvider) Apply(e *Markup) {
+	e.Provide(c.Key, c.Value)
+}
+
+// ContextBind returns a function which retrieves the context value for the
+// key from the markup, usable as the bind of CSSStylesheet.
+func ContextBind(key interface{}) func(*Markup) interface{} {
+	return func(owner *Markup) interface{} {
+		return owner.ContextValue(key)
+	}
+}
+
+// NewContextText returns a new text markup whose text content is created on
+// every render by the function, which can retrieve context values from the
+// text markup (e.g translations for the locale of the tree).
+func NewContextText(fn func(*Markup) string) *Markup {
+	em := NewText("")
+	em.textContentFn = fn
+	return em
+}
+
+// copyContext returns a copy of the context values.
+func copyContext(context map[interface{}]interface{}) map[interface{}]interface{} {
+	if context == nil {
+		return nil
+	}
+
+	co := make(map[interface{}]interface{}, len(context))
+	for key, value := range context {
+		co[key] = value
+	}
+
+	return co
+}
@@ -0,0 +1,56 @@
+package trees_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+type theme struct {
+	Color string
+}
+
+func TestContextValues(t *testing.T) {
+	page := trees.NewMarkup("div", false)
+	trees.WithContext("theme", theme{Color: "red"}).Apply(page)
+	page.Provide("locale", "en")
+
+	section := trees.NewMarkup("section", false)
+	section.Provide("locale", "fr")
+	page.AddChild(section)
+
+	greetings := map[string]string{"en": "Hello", "fr": "Bonjour"}
+	greeting := trees.NewContextText(func(owner *trees.Markup) string {
+		locale, _ := owner.ContextValue("locale").(string)
+		return greetings[locale]
+	})
+	section.AddChild(greeting)
+
+	if locale, ok := greeting.Context("locale"); !ok || locale != "fr" || page.ContextValue("locale") != "en" {
+		t.Fatalf("\t%s\t  Should have found closest context value: %v", failed, locale)
+	}
+
+	if _, ok := page.Context("user"); ok {
+		t.Fatalf("\t%s\t  Should have not found missing context value", failed)
+	}
+	t.Logf("\t%s\t  Should have found closest context value", success)
+
+	if !strings.Contains(page.HTML(), "Bonjour") {
+		t.Fatalf("\t%s\t  Should have rendered text from context: %s", failed, page.HTML())
+	}
+	t.Logf("\t%s\t  Should have rendered text from context", success)
+
+	sheet := trees.CSSStylesheet(`& { color: {{ .Color }}; }`, trees.ContextBind("theme"), nil, false)
+	section.AddChild(sheet)
+
+	if !strings.Contains(page.HTML(), "color: red") {
+		t.Fatalf("\t%s\t  Should have rendered stylesheet from context: %s", failed, page.HTML())
+	}
+	t.Logf("\t%s\t  Should have rendered stylesheet from context", success)
+
+	if snap := page.Snapshot(); !strings.Contains(snap.HTML(), "Bonjour") {
+		t.Fatalf("\t%s\t  Should have kept context values in snapshot", failed)
+	}
+	t.Logf("\t%s\t  Should have kept context values in snapshot", success)
+}
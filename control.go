@@ -0,0 +1,144 @@
+package trees
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Fragment defines a list of Appliables which are applied together to a
+// markup without any wrapping element.
+type Fragment []Appliable
+
+// Apply applies all items of the fragment to the markup, skipping nil items.
+func (f Fragment) Apply(m *Markup) {
+	if m == nil {
+		return
+	}
+
+	for _, item := range f {
+		if item == nil {
+			continue
+		}
+
+		if mo, ok := item.(*Markup); ok && mo == nil {
+			continue
+		}
+
+		item.Apply(m)
+	}
+}
+
+// Range returns a Fragment with the markup rendered for each item of the
+// giving slice or array. If keyFn is not nil, the key it returns for each item
+// is set on the item's markup so it is matched by key during Reconcile. A
+// value which is not a slice or array panics.
+func Range(items interface{}, keyFn func(index int, item interface{}) string, renderFn func(index int, item interface{}) *Markup) Fragment {
+	value := reflect.ValueOf(items)
+	if !value.IsValid() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		panic(fmt.Sprintf("Range expects a slice or array, got %T", items))
+	}
+
+	fragment := make(Fragment, 0, value.Len())
+
+	for index := 0; index < value.Len(); index++ {
+		item := value.Index(index).Interface()
+
+		rendered := renderFn(index, item)
+		if rendered == nil {
+			continue
+		}
+
+		if keyFn != nil {
+			rendered.SetKey(keyFn(index, item))
+		}
+
+		fragment = append(fragment, rendered)
+	}
+
+	return fragment
+}
+
+// IfElse returns a Appliable which calls then if the state is true or
+// otherwise if false when applied, applying the result. Either function can
+// be nil.
+func IfElse(state bool, then func() Appliable, otherwise func() Appliable) Appliable {
+	return lazyApplier(func() Appliable {
+		if state {
+			if then != nil {
+				return then()
+			}
+
+			return nil
+		}
+
+		if otherwise != nil {
+			return otherwise()
+		}
+
+		return nil
+	})
+}
+
+// SwitchCase defines a Appliable which applies the result of the first case
+// matching its value, or the default if none matches. Cases are only called
+// when applied.
+type SwitchCase struct {
+	value    interface{}
+	cases    []switchCase
+	fallback func() Appliable
+}
+
+// switchCase defines a case of a SwitchCase.
+type switchCase struct {
+	match interface{}
+	fn    func() Appliable
+}
+
+// Switch returns a new SwitchCase for the value.
+func Switch(value interface{}) *SwitchCase {
+	return &SwitchCase{value: value}
+}
+
+// Case adds a case for the match, compared with the value using
+// reflect.DeepEqual.
+func (s *SwitchCase) Case(match interface{}, fn func() Appliable) *SwitchCase {
+	s.cases = append(s.cases, switchCase{match: match, fn: fn})
+	return s
+}
+
+// Default sets the function used when no case matches.
+func (s *SwitchCase) Default(fn func() Appliable) *SwitchCase {
+	s.fallback = fn
+	return s
+}
+
+// Apply applies the result of the matching case to the markup.
+func (s *SwitchCase) Apply(m *Markup) {
+	for _, item := range s.cases {
+		if reflect.DeepEqual(item.match, s.value) {
+			lazyApplier(item.fn).Apply(m)
+			return
+		}
+	}
+
+	lazyApplier(s.fallback).Apply(m)
+}
+
+// lazyApplier defines a function type which implements Appliable, applying
+// the result of the function.
+type lazyApplier func() Appliable
+
+// Apply calls the function and applies its result to the markup.
+func (fn lazyApplier) Apply(m *Markup) {
+	if fn == nil {
+		return
+	}
+
+	Fragment{fn()}.Apply(m)
+}
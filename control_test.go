@@ -0,0 +1,108 @@
+package trees_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+	"github.com/gu-io/trees/notifications"
+)
+
+type todo struct {
+	ID    string
+	Title string
+}
+
+func renderTodos(todos []todo, status string) *trees.Markup {
+	list := trees.NewMarkup("ul", false)
+
+	trees.Range(todos, func(_ int, item interface{}) string {
+		return item.(todo).ID
+	}, func(_ int, item interface{}) *trees.Markup {
+		li := trees.NewMarkup("li", false)
+		trees.NewText("%s", item.(todo).Title).Apply(li)
+		return li
+	}).Apply(list)
+
+	trees.Switch(status).Case("empty", func() trees.Appliable {
+		return trees.NewText("Nothing to do")
+	}).Case("done", func() trees.Appliable {
+		return trees.NewText("All done")
+	}).Default(nil).Apply(list)
+
+	trees.IfElse(len(todos) > 2, func() trees.Appliable {
+		return trees.NewMarkup("footer", false)
+	}, nil).Apply(list)
+
+	return list
+}
+
+func TestControlBuilders(t *testing.T) {
+	todos := []todo{{ID: "a", Title: "Write"}, {ID: "b", Title: "Test"}, {ID: "c", Title: "Ship"}}
+
+	first := renderTodos(todos, "done")
+	if len(first.Children()) != 5 || first.Children()[0].Key() != "a" || !strings.Contains(first.HTML(), "All done") {
+		t.Fatalf("\t%s\t  Should have rendered keyed list with branches: %s", failed, first.HTML())
+	}
+
+	if other := renderTodos(nil, "empty"); len(other.Children()) != 1 || !strings.Contains(other.HTML(), "Nothing to do") {
+		t.Fatalf("\t%s\t  Should have rendered branches for empty list: %s", failed, other.HTML())
+	}
+
+	if other := renderTodos(todos[:1], "unknown"); len(other.Children()) != 1 {
+		t.Fatalf("\t%s\t  Should have rendered nothing for default and else branches", failed)
+	}
+	t.Logf("\t%s\t  Should have rendered keyed list with branches", success)
+
+	uids := map[string]string{}
+	for _, child := range first.Children()[:3] {
+		uids[child.Key()] = child.UID()
+	}
+
+	reordered := []todo{todos[2], todos[0], todos[1]}
+	second := renderTodos(reordered, "done")
+
+	if !second.Reconcile(first) {
+		t.Fatalf("\t%s\t  Should have marked reordered list as changed", failed)
+	}
+
+	for _, child := range second.Children()[:3] {
+		if uids[child.Key()] != child.UID() {
+			t.Fatalf("\t%s\t  Should have matched list items by key: %s", failed, child.Key())
+		}
+	}
+	t.Logf("\t%s\t  Should have matched list items by key", success)
+}
+
+func TestControlRemovedKeyedChild(t *testing.T) {
+	var clicks int
+
+	render := func(keys ...string) *trees.Markup {
+		list := trees.NewMarkup("ul", false)
+
+		trees.Range(keys, func(_ int, item interface{}) string {
+			return item.(string)
+		}, func(_ int, item interface{}) *trees.Markup {
+			li := trees.NewMarkup("li", false)
+			events.ClickEvent(func() { clicks++ }).Apply(li)
+			return li
+		}).Apply(list)
+
+		return list
+	}
+
+	first := render("a", "b")
+	removed := first.Children()[1].Events()[0]
+
+	second := render("a")
+	defer second.Release()
+
+	second.Reconcile(first)
+
+	notifications.Dispatch(trees.EventBroadcast{EventName: "ClickEvent", EventID: removed.ID(), Event: &trees.BaseEvent{Type: "click"}})
+	if clicks != 0 {
+		t.Fatalf("\t%s\t  Should have unsubscribed handler of removed keyed child: %d", failed, clicks)
+	}
+	t.Logf("\t%s\t  Should have unsubscribed handler of removed keyed child", success)
+}
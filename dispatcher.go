@@ -0,0 +1,187 @@
+package trees
+
+import (
+	"sync"
+
+	"github.com/gu-io/trees/notifications"
+)
+
+// Dispatcher defines a event dispatcher scoped to a tree or session, which
+// routes each EventBroadcast only to the handlers subscribed for its event id.
+// Events use the dispatcher given with the EventDispatcher option, else the
+// dispatcher of their closest markup set through Apply, else the global
+// notifications dispatcher.
+type Dispatcher struct {
+	ml         sync.RWMutex
+	handlers   map[string][]*dispatchHandler
+	middleware []Middleware
+	runner     func(call func())
+}
+
+// dispatchHandler defines a handler subscribed to a Dispatcher.
+type dispatchHandler struct {
+	handle func(EventBroadcast)
+}
+
+// NewDispatcher returns a new instance of a Dispatcher.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[string][]*dispatchHandler),
+	}
+}
+
+// EventDispatcher sets the dispatcher the event is subscribed with, instead
+// of the dispatcher of its markup.
+func EventDispatcher(d *Dispatcher) EventOptions {
+	return func(ev *Event) {
+		ev.dispatcher = d
+	}
+}
+
+// Apply sets the dispatcher for the markup and its descendants, moving the
+// subscriptions of their events to it.
+func (d *Dispatcher) Apply(e *Markup) {
+	if e.frozen {
+		return
+	}
+
+	e.dispatcher = d
+	e.subscribeEvents()
+}
+
+// Dispatcher returns the dispatcher of the markup or of its closest parent
+// with one, if any.
+func (e *Markup) Dispatcher() *Dispatcher {
+	for node := e; node != nil; node = node.parent {
+		if node.dispatcher != nil {
+			return node.dispatcher
+		}
+	}
+
+	return nil
+}
+
+// Subscribe adds the function as handler of the broadcasts with the event id,
+// returning the Remover which unsubscribes it.
+func (d *Dispatcher) Subscribe(eventID string, fn func(EventBroadcast)) notifications.Remover {
+	handler := &dispatchHandler{handle: fn}
+
+	d.ml.Lock()
+	d.handlers[eventID] = append(d.handlers[eventID], handler)
+	d.ml.Unlock()
+
+	return &dispatchRemover{root: d, id: eventID, handler: handler}
+}
+
+// Len returns the total number of handlers subscribed to the dispatcher.
+func (d *Dispatcher) Len() int {
+	d.ml.RLock()
+	defer d.ml.RUnlock()
+
+	var total int
+	for _, handlers := range d.handlers {
+		total += len(handlers)
+	}
+
+	return total
+}
+
+// UseRunner sets the function making the delayed calls of the debounced
+// handlers of the events subscribed with the dispatcher, which must call the
+// function while holding the lock guarding the tree (e.g the lock of a
+// session). Without a runner they are called within Markup.Update of their
+// markup.
+func (d *Dispatcher) UseRunner(runner func(call func())) {
+	d.ml.Lock()
+	defer d.ml.Unlock()
+
+	d.runner = runner
+}
+
+// run calls the function through the runner of the dispatcher, returning
+// false if it has none.
+func (d *Dispatcher) run(call func()) bool {
+	d.ml.RLock()
+	runner := d.runner
+	d.ml.RUnlock()
+
+	if runner == nil {
+		return false
+	}
+
+	runner(call)
+	return true
+}
+
+// Dispatch delivers the broadcast to the handlers subscribed for its event
+// id, returning the number of handlers called. Handlers can subscribe and
+// unsubscribe while being called.
+func (d *Dispatcher) Dispatch(evm EventBroadcast) int {
+	d.ml.RLock()
+	handlers := append([]*dispatchHandler(nil), d.handlers[evm.EventID]...)
+	d.ml.RUnlock()
+
+	for _, handler := range handlers {
+		handler.handle(evm)
+	}
+
+	return len(handlers)
+}
+
+// Handle implements the notifications.EventDistributor interface, dispatching
+// EventBroadcast values and ignoring others. It allows the dispatcher to
+// receive the broadcasts of another notifications pipeline.
+func (d *Dispatcher) Handle(item interface{}) {
+	if evm, ok := item.(EventBroadcast); ok {
+		d.Dispatch(evm)
+	}
+}
+
+// unsubscribe removes the handler for the event id.
+func (d *Dispatcher) unsubscribe(eventID string, handler *dispatchHandler) {
+	d.ml.Lock()
+	defer d.ml.Unlock()
+
+	handlers := d.handlers[eventID]
+
+	for index, item := range handlers {
+		if item != handler {
+			continue
+		}
+
+		handlers = append(handlers[:index:index], handlers[index+1:]...)
+		break
+	}
+
+	if len(handlers) == 0 {
+		delete(d.handlers, eventID)
+		return
+	}
+
+	d.handlers[eventID] = handlers
+}
+
+// dispatchRemover implements the notifications.Remover interface for handlers
+// of a Dispatcher.
+type dispatchRemover struct {
+	root    *Dispatcher
+	id      string
+	handler *dispatchHandler
+	fn      []func()
+}
+
+// Add adds a callback to be called when Remove is called.
+func (r *dispatchRemover) Add(fn func()) {
+	r.fn = append(r.fn, fn)
+}
+
+// Remove unsubscribes the handler and calls the added callbacks.
+func (r *dispatchRemover) Remove() {
+	r.root.unsubscribe(r.id, r.handler)
+
+	for _, fx := range r.fn {
+		fx()
+	}
+
+	r.fn = nil
+}
@@ -0,0 +1,81 @@
+package trees_test
+
+import (
+	"testing"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+	"github.com/gu-io/trees/notifications"
+)
+
+func TestDispatcher(t *testing.T) {
+	first, second := trees.NewDispatcher(), trees.NewDispatcher()
+
+	var firstCalls, secondCalls int
+
+	firstRoot := trees.NewMarkup("div", false)
+	first.Apply(firstRoot)
+
+	firstButton := trees.NewMarkup("button", false)
+	firstButton.SwapUID("shared")
+	events.ClickEvent(func() { firstCalls++ }).Apply(firstButton)
+	firstButton.Apply(firstRoot)
+
+	secondRoot := trees.NewMarkup("div", false)
+	second.Apply(secondRoot)
+
+	secondButton := trees.NewMarkup("button", false)
+	secondButton.SwapUID("shared")
+	secondButton.Apply(secondRoot)
+	events.ClickEvent(func() { secondCalls++ }).Apply(secondButton)
+
+	if first.Len() != 1 || second.Len() != 1 {
+		t.Fatalf("\t%s\t  Should have subscribed events to dispatcher of their root: %d, %d", failed, first.Len(), second.Len())
+	}
+	t.Logf("\t%s\t  Should have subscribed events to dispatcher of their root", success)
+
+	click := firstButton.Events()[0]
+	payload := trees.EventBroadcast{EventName: "ClickEvent", EventID: click.ID(), Event: &trees.BaseEvent{Type: "click"}}
+
+	notifications.Dispatch(payload)
+	if firstCalls != 0 || secondCalls != 0 {
+		t.Fatalf("\t%s\t  Should have not received events from global dispatcher: %d, %d", failed, firstCalls, secondCalls)
+	}
+	t.Logf("\t%s\t  Should have not received events from global dispatcher", success)
+
+	if called := first.Dispatch(payload); called != 1 || firstCalls != 1 || secondCalls != 0 {
+		t.Fatalf("\t%s\t  Should have routed event to handlers of its dispatcher only: %d, %d", failed, firstCalls, secondCalls)
+	}
+	t.Logf("\t%s\t  Should have routed event to handlers of its dispatcher only", success)
+
+	if called := first.Dispatch(trees.EventBroadcast{EventID: "button[uid='unknown']#click"}); called != 0 {
+		t.Fatalf("\t%s\t  Should have called no handler for unknown event id: %d", failed, called)
+	}
+	t.Logf("\t%s\t  Should have called no handler for unknown event id", success)
+
+	firstButton.SwapUID("swapped")
+	swapped := firstButton.Events()[0]
+
+	if first.Dispatch(payload) != 0 || first.Dispatch(trees.EventBroadcast{EventID: swapped.ID(), Event: &trees.BaseEvent{}}) != 1 {
+		t.Fatalf("\t%s\t  Should have moved subscription to new event id", failed)
+	}
+	t.Logf("\t%s\t  Should have moved subscription to new event id", success)
+
+	third := trees.NewDispatcher()
+
+	var explicit int
+	events.ClickEvent(func() { explicit++ }, trees.EventDispatcher(third)).Apply(firstRoot)
+
+	if third.Len() != 1 || first.Len() != 1 {
+		t.Fatalf("\t%s\t  Should have subscribed event to its explicit dispatcher: %d, %d", failed, third.Len(), first.Len())
+	}
+	t.Logf("\t%s\t  Should have subscribed event to its explicit dispatcher", success)
+
+	firstRoot.Empty()
+	secondRoot.Empty()
+
+	if first.Len() != 0 || second.Len() != 0 || third.Len() != 0 {
+		t.Fatalf("\t%s\t  Should have unsubscribed events of emptied roots: %d, %d, %d", failed, first.Len(), second.Len(), third.Len())
+	}
+	t.Logf("\t%s\t  Should have unsubscribed events of emptied roots", success)
+}
@@ -5,4 +5,16 @@
 // browser DOM yet capable of being generated into one and spots a quick
 // virtual diffing system that makes it easy to upgrade existing markup with
 // less cycles.
+//
+// Markup are not safe for concurrent use, the methods of a markup, the
+// helpers of this package, ApplyMorphers and ElementWriter.Print do not
+// synchronize access to the tree. To share a tree between goroutines (e.g a
+// tree changed by morphers and events while being printed by http handlers)
+// every change must be made within a call to Markup.Update and every read
+// within a call to Markup.View, both of which are guarded by a lock held by
+// the root of the tree. Trees which need to be
+// printed or diffed while the live tree keeps changing should use
+// Markup.Snapshot, which returns a frozen copy of the tree that can be used
+// freely by any goroutine. Snapshots only copy the properties of the markup
+// changed since the last snapshot, sharing those of the unchanged markup.
 package trees
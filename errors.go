@@ -21,3 +21,12 @@ var ErrNotFound = errors.New("Item not found")
 
 // ErrNotStyle relating to the style types
 var ErrNotStyle = errors.New("Value type is not a Style type")
+
+// ErrNotNodeSet is returned when a XPath expression does not result in a node-set
+var ErrNotNodeSet = errors.New("XPath expression does not result in a node-set")
+
+// ErrTransactionDone is returned when a transaction was already committed or rolled back
+var ErrTransactionDone = errors.New("Transaction already committed or rolled back")
+
+// ErrTransactionOpen is returned when a transaction still has open nested transactions
+var ErrTransactionOpen = errors.New("Transaction has open nested transactions")
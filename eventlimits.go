@@ -0,0 +1,299 @@
+package trees
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Debounce sets the duration the event must be quiet for before its handler
+// is called with the last event, both by the client runtime and on delivery.
+//
+// Debounced handlers are called from another goroutine once the duration
+// passed, through the runner of the event's dispatcher (see
+// Dispatcher.UseRunner) else within Markup.Update of their markup, so they
+// must not call Update or View of the same tree themselves. Being called
+// after DispatchEvent returned, their calls to StopPropagation and
+// PreventDefault have no effect on the dispatch, only the flags of the Event
+// do.
+func Debounce(d time.Duration) EventOptions {
+	return func(ev *Event) {
+		ev.Debounce = d
+	}
+}
+
+// Throttle sets the duration within which the handler of the event is called
+// at most once, the first event being delivered and the others dropped, both
+// by the client runtime and on delivery.
+func Throttle(d time.Duration) EventOptions {
+	return func(ev *Event) {
+		ev.Throttle = d
+	}
+}
+
+// Once sets the handler of the event to be called only for the first event
+// delivered to each markup.
+func Once() EventOptions {
+	return func(ev *Event) {
+		ev.Once = true
+	}
+}
+
+// Passive sets the event as passive, with its handler unable to prevent the
+// default action of the event.
+func Passive() EventOptions {
+	return func(ev *Event) {
+		ev.Passive = true
+	}
+}
+
+// Keys sets the keys the keyboard events must match to be delivered, each
+// being a key value (e.g "Enter", "s") optionally prefixed with the modifiers
+// which must be held joined with "+" (e.g "ctrl+s", "ctrl+shift+Enter").
+// Keys and modifiers are matched case insensitively and modifiers which are
+// not listed must not be held. Other events are not filtered.
+func Keys(keys ...string) EventOptions {
+	return func(ev *Event) {
+		ev.Keys = append(ev.Keys, keys...)
+	}
+}
+
+// handle delivers the event object to the handler of the event, filtering it
+// by keys and applying the once, throttle and debounce limits.
+func (e *Event) handle(ev EventObject, m *Markup) {
+	if !e.matchKeys(ev) {
+		return
+	}
+
+	if e.limiter == nil {
+		e.call(ev, m)
+		return
+	}
+
+	e.limiter.run(ev, m, e.call, e.delayed)
+}
+
+// delayed makes the delayed call of the debounced handler of the event
+// through the runner of its dispatcher, else within Update of the markup.
+func (e *Event) delayed(m *Markup, call func()) {
+	if e.scope != nil && e.scope.run(call) {
+		return
+	}
+
+	m.Update(func(*Markup) {
+		call()
+	})
+}
+
+// matchKeys returns true/false if the keyboard event matches the keys of the
+// event, returning true for any other event.
+func (e *Event) matchKeys(ev EventObject) bool {
+	if len(e.Keys) == 0 {
+		return true
+	}
+
+	keyboard, ok := ev.(*KeyboardEvent)
+	if !ok {
+		return true
+	}
+
+	for _, key := range e.Keys {
+		if matchKey(key, keyboard) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchKey returns true/false if the keyboard event matches the key
+// combination.
+func matchKey(combination string, ev *KeyboardEvent) bool {
+	parts := strings.Split(combination, "+")
+	key := parts[len(parts)-1]
+
+	// allows "+" and "ctrl++" to match the plus key.
+	if key == "" && len(parts) > 1 {
+		key = "+"
+		parts = parts[:len(parts)-1]
+	}
+
+	var mods ModifierKeys
+
+	for _, mod := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(mod)) {
+		case "ctrl", "control":
+			mods.CtrlKey = true
+		case "alt", "option":
+			mods.AltKey = true
+		case "shift":
+			mods.ShiftKey = true
+		case "meta", "cmd", "command":
+			mods.MetaKey = true
+		default:
+			return false
+		}
+	}
+
+	return mods == ev.ModifierKeys && strings.EqualFold(key, ev.Key)
+}
+
+// eventLimiter defines the once, throttle and debounce limits of an event
+// added to a markup, with their state.
+type eventLimiter struct {
+	ml       sync.Mutex
+	once     bool
+	throttle time.Duration
+	debounce time.Duration
+	state    *limitState
+}
+
+// limitState defines the state of the limits of an event, kept by the event
+// replacing it through Reconcile, with the pending debounced call if any.
+type limitState struct {
+	ml    sync.Mutex
+	last  time.Time
+	timer *time.Timer
+	done  bool
+	call  func(EventObject, *Markup)
+	delay func(*Markup, func())
+	m     *Markup
+}
+
+// newEventLimiter returns a new eventLimiter for the limits of the event, or
+// nil if it has none.
+func newEventLimiter(e *Event) *eventLimiter {
+	if !e.Once && e.Throttle <= 0 && e.Debounce <= 0 {
+		return nil
+	}
+
+	return &eventLimiter{once: e.Once, throttle: e.Throttle, debounce: e.Debounce, state: &limitState{}}
+}
+
+// current returns the state of the limiter.
+func (l *eventLimiter) current() *limitState {
+	l.ml.Lock()
+	defer l.ml.Unlock()
+
+	return l.state
+}
+
+// run calls the function with the event object and markup if allowed by the
+// limits, through delay after the debounce duration if any.
+func (l *eventLimiter) run(ev EventObject, m *Markup, fn func(EventObject, *Markup), delay func(*Markup, func())) {
+	st := l.current()
+	st.ml.Lock()
+
+	if st.done {
+		st.ml.Unlock()
+		return
+	}
+
+	if l.throttle > 0 {
+		now := time.Now()
+		if !st.last.IsZero() && now.Sub(st.last) < l.throttle {
+			st.ml.Unlock()
+			return
+		}
+
+		st.last = now
+	}
+
+	if l.debounce > 0 {
+		if st.timer != nil {
+			st.timer.Stop()
+		}
+
+		st.call, st.delay, st.m = fn, delay, m
+
+		var timer *time.Timer
+		timer = time.AfterFunc(l.debounce, func() {
+			st.ml.Lock()
+			delay, target := st.delay, st.m
+			st.ml.Unlock()
+
+			delay(target, func() {
+				st.ml.Lock()
+
+				// skip calls whose timer was replaced or stopped while firing.
+				if st.done || st.timer != timer {
+					st.ml.Unlock()
+					return
+				}
+
+				st.done = l.once
+				st.timer = nil
+				call, target := st.call, st.m
+				st.ml.Unlock()
+
+				call(ev, target)
+			})
+		})
+
+		st.timer = timer
+		st.ml.Unlock()
+		return
+	}
+
+	st.done = l.once
+	st.ml.Unlock()
+
+	fn(ev, m)
+}
+
+// adopt takes over the state of the limiter of the event replaced by the
+// event of the limiter, if both have the same limits, making the pending
+// debounced call with the function and markup of the new event through the
+// delay of the event which received it.
+func (l *eventLimiter) adopt(old *eventLimiter, fn func(EventObject, *Markup), m *Markup) {
+	if old == nil || old == l || old.once != l.once || old.throttle != l.throttle || old.debounce != l.debounce {
+		return
+	}
+
+	old.ml.Lock()
+	st := old.state
+	old.state = &limitState{}
+	old.ml.Unlock()
+
+	st.ml.Lock()
+	if st.timer != nil {
+		st.call, st.m = fn, m
+	}
+	st.ml.Unlock()
+
+	l.ml.Lock()
+	l.state = st
+	l.ml.Unlock()
+}
+
+// stop stops the pending debounced call, if any.
+func (l *eventLimiter) stop() {
+	st := l.current()
+
+	st.ml.Lock()
+	defer st.ml.Unlock()
+
+	if st.timer != nil {
+		st.timer.Stop()
+		st.timer = nil
+	}
+}
+
+// adoptLimits moves the state of the limits of the events of the old markup
+// to the events of the markup with the same id, so the once, throttle and
+// pending debounced calls of events survive their markup being replaced.
+func (e *Markup) adoptLimits(old *Markup) {
+	for index := range e.events {
+		ev := e.events[index]
+		if ev.limiter == nil {
+			continue
+		}
+
+		for _, oev := range old.events {
+			if oev.limiter != nil && oev.ID() == ev.ID() {
+				ev.limiter.adopt(oev.limiter, ev.call, e)
+				break
+			}
+		}
+	}
+}
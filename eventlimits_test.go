@@ -0,0 +1,167 @@
+package trees_test
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+)
+
+func TestEventLimits(t *testing.T) {
+	input := trees.NewMarkup("input", false)
+	defer input.Empty()
+
+	var enters, saves int32
+
+	events.KeyDownEvent(func() { atomic.AddInt32(&enters, 1) }, trees.Keys("Enter")).Apply(input)
+	events.KeyDownEvent(func() { atomic.AddInt32(&saves, 1) }, trees.Keys("ctrl+s")).Apply(input)
+
+	input.DispatchEvent("keydown", &trees.KeyboardEvent{Key: "a"})
+	input.DispatchEvent("keydown", &trees.KeyboardEvent{Key: "Enter"})
+	input.DispatchEvent("keydown", &trees.KeyboardEvent{Key: "s"})
+	input.DispatchEvent("keydown", &trees.KeyboardEvent{Key: "S", ModifierKeys: trees.ModifierKeys{CtrlKey: true}})
+
+	if enters != 1 || saves != 1 {
+		t.Fatalf("\t%s\t  Should have filtered keyboard events by keys: %d, %d", failed, enters, saves)
+	}
+	t.Logf("\t%s\t  Should have filtered keyboard events by keys", success)
+
+	var once, throttled, debounced int32
+	var last atomic.Value
+
+	events.InputEvent(func() { atomic.AddInt32(&once, 1) }, trees.Once()).Apply(input)
+	events.InputEvent(func() { atomic.AddInt32(&throttled, 1) }, trees.Throttle(time.Hour)).Apply(input)
+	events.InputEvent(func(ev *trees.InputEvent) {
+		atomic.AddInt32(&debounced, 1)
+		last.Store(ev.Value)
+	}, trees.Debounce(20*time.Millisecond)).Apply(input)
+
+	for _, value := range []string{"t", "tr", "tre", "tree"} {
+		input.DispatchEvent("input", &trees.InputEvent{Value: value})
+	}
+
+	if once != 1 || throttled != 1 {
+		t.Fatalf("\t%s\t  Should have delivered once and throttled events once: %d, %d", failed, once, throttled)
+	}
+	t.Logf("\t%s\t  Should have delivered once and throttled events once", success)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&debounced) != 1 || last.Load() != "tree" {
+		t.Fatalf("\t%s\t  Should have delivered last debounced event: %d, %v", failed, debounced, last.Load())
+	}
+	t.Logf("\t%s\t  Should have delivered last debounced event", success)
+
+	link := trees.NewMarkup("a", false)
+	defer link.Empty()
+
+	events.ClickEvent(func(ev *trees.MouseEvent) {
+		ev.PreventDefault()
+	}, trees.Passive()).Apply(link)
+
+	if !link.DispatchEvent("click", &trees.MouseEvent{BaseEvent: trees.BaseEvent{Cancelable: true}}) {
+		t.Fatalf("\t%s\t  Should have ignored default prevented by passive handler", failed)
+	}
+	t.Logf("\t%s\t  Should have ignored default prevented by passive handler", success)
+
+	search := events.InputEvent(func() {}, trees.Debounce(300*time.Millisecond), trees.Keys("Enter"), trees.Passive())
+
+	data, err := json.Marshal(search.EventJSON())
+	if err != nil {
+		t.Fatalf("\t%s\t  Should have encoded event json: %+q", failed, err)
+	}
+
+	var decoded trees.EventJSON
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Debounce != 300 || !decoded.Passive || len(decoded.Keys) != 1 {
+		t.Fatalf("\t%s\t  Should have emitted event options in json: %s", failed, data)
+	}
+	t.Logf("\t%s\t  Should have emitted event options in json", success)
+}
+
+func TestDebouncedEventsLocking(t *testing.T) {
+	form := trees.NewMarkup("form", false)
+	input := trees.NewMarkup("input", false)
+	form.AddChild(input)
+	defer form.Empty()
+
+	var calls int32
+
+	events.InputEvent(func() {
+		atomic.AddInt32(&calls, 1)
+	}, trees.Debounce(10*time.Millisecond)).Apply(input)
+
+	form.Update(func(*trees.Markup) {
+		input.DispatchEvent("input", &trees.InputEvent{})
+		time.Sleep(50 * time.Millisecond)
+
+		if atomic.LoadInt32(&calls) != 0 {
+			t.Fatalf("\t%s\t  Should have waited for the lock of the tree", failed)
+		}
+	})
+
+	time.Sleep(50 * time.Millisecond)
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("\t%s\t  Should have called debounced handler within Update: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have called debounced handler within Update", success)
+
+	dispatcher := trees.NewDispatcher()
+	dispatcher.Apply(form)
+
+	var ran int32
+	dispatcher.UseRunner(func(call func()) {
+		atomic.AddInt32(&ran, 1)
+		call()
+	})
+
+	input.DispatchEvent("input", &trees.InputEvent{})
+	time.Sleep(50 * time.Millisecond)
+
+	if atomic.LoadInt32(&ran) != 1 || atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("\t%s\t  Should have called debounced handler through dispatcher runner: %d, %d", failed, ran, calls)
+	}
+	t.Logf("\t%s\t  Should have called debounced handler through dispatcher runner", success)
+}
+
+func TestEventLimitsReconcile(t *testing.T) {
+	var once, debounced int32
+	var last atomic.Value
+
+	render := func() *trees.Markup {
+		input := trees.NewMarkup("input", false)
+
+		events.ClickEvent(func() { atomic.AddInt32(&once, 1) }, trees.Once()).Apply(input)
+		events.InputEvent(func(ev *trees.InputEvent) {
+			atomic.AddInt32(&debounced, 1)
+			last.Store(ev.Value)
+		}, trees.Debounce(20*time.Millisecond)).Apply(input)
+
+		return input
+	}
+
+	old := render()
+	old.DispatchEvent("click", &trees.MouseEvent{})
+	old.DispatchEvent("input", &trees.InputEvent{Value: "tree"})
+
+	current := render()
+	defer current.Release()
+
+	current.Reconcile(old)
+	current.DispatchEvent("click", &trees.MouseEvent{})
+
+	if atomic.LoadInt32(&once) != 1 {
+		t.Fatalf("\t%s\t  Should have kept once limit of reconciled event: %d", failed, once)
+	}
+	t.Logf("\t%s\t  Should have kept once limit of reconciled event", success)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&debounced) != 1 || last.Load() != "tree" {
+		t.Fatalf("\t%s\t  Should have kept pending debounced call of reconciled event: %d, %v", failed, debounced, last.Load())
+	}
+	t.Logf("\t%s\t  Should have kept pending debounced call of reconciled event", success)
+}
@@ -0,0 +1,275 @@
+package trees
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// BaseEvent defines the fields shared by all DOM events decoded from the json
+// payloads sent by a client. It implements EventObject and is used for all
+// event types without a more specific type.
+type BaseEvent struct {
+	Type             string  `json:"type"`
+	Target           string  `json:"target"`
+	CurrentTarget    string  `json:"currentTarget"`
+	TimeStamp        float64 `json:"timeStamp"`
+	Bubbles          bool    `json:"bubbles"`
+	Cancelable       bool    `json:"cancelable"`
+	DefaultPrevented bool    `json:"defaultPrevented"`
+	IsTrusted        bool    `json:"isTrusted"`
+
+	raw         json.RawMessage
+	remove      func()
+	propagation propagation
+}
+
+// RemoveEvent calls the remover attached to the event, if any.
+func (b *BaseEvent) RemoveEvent() {
+	if b.remove != nil {
+		b.remove()
+	}
+}
+
+// Underlying returns the raw json payload the event was decoded from.
+func (b *BaseEvent) Underlying() interface{} {
+	return b.raw
+}
+
+// Raw returns the raw json payload the event was decoded from.
+func (b *BaseEvent) Raw() json.RawMessage {
+	return b.raw
+}
+
+// SetRemover sets the function called by RemoveEvent.
+func (b *BaseEvent) SetRemover(fn func()) {
+	b.remove = fn
+}
+
+// base returns the BaseEvent, allowing the decoder to reach it through all
+// event types embedding it.
+func (b *BaseEvent) base() *BaseEvent {
+	return b
+}
+
+// ModifierKeys defines the state of the modifier keys during an event.
+type ModifierKeys struct {
+	AltKey   bool `json:"altKey"`
+	CtrlKey  bool `json:"ctrlKey"`
+	ShiftKey bool `json:"shiftKey"`
+	MetaKey  bool `json:"metaKey"`
+}
+
+// MouseEvent defines the payload of mouse events (e.g click, mousedown).
+type MouseEvent struct {
+	BaseEvent
+	ModifierKeys
+
+	Detail        int     `json:"detail"`
+	Button        int     `json:"button"`
+	Buttons       int     `json:"buttons"`
+	ScreenX       float64 `json:"screenX"`
+	ScreenY       float64 `json:"screenY"`
+	ClientX       float64 `json:"clientX"`
+	ClientY       float64 `json:"clientY"`
+	PageX         float64 `json:"pageX"`
+	PageY         float64 `json:"pageY"`
+	OffsetX       float64 `json:"offsetX"`
+	OffsetY       float64 `json:"offsetY"`
+	MovementX     float64 `json:"movementX"`
+	MovementY     float64 `json:"movementY"`
+	RelatedTarget string  `json:"relatedTarget"`
+}
+
+// KeyboardEvent defines the payload of keyboard events (e.g keydown).
+type KeyboardEvent struct {
+	BaseEvent
+	ModifierKeys
+
+	Key         string `json:"key"`
+	Code        string `json:"code"`
+	Location    int    `json:"location"`
+	Repeat      bool   `json:"repeat"`
+	IsComposing bool   `json:"isComposing"`
+}
+
+// InputEvent defines the payload of input and change events, including the
+// value and checked state of the target.
+type InputEvent struct {
+	BaseEvent
+
+	Data        string `json:"data"`
+	InputType   string `json:"inputType"`
+	IsComposing bool   `json:"isComposing"`
+	Value       string `json:"value"`
+	Checked     bool   `json:"checked"`
+}
+
+// FocusEvent defines the payload of focus events (e.g focus, blur).
+type FocusEvent struct {
+	BaseEvent
+
+	RelatedTarget string `json:"relatedTarget"`
+}
+
+// SubmitEvent defines the payload of form submissions, including the values
+// of the form.
+type SubmitEvent struct {
+	BaseEvent
+
+	Submitter string              `json:"submitter"`
+	Values    map[string][]string `json:"values"`
+}
+
+// DataTransfer defines the data being dragged in a DragEvent.
+type DataTransfer struct {
+	DropEffect    string            `json:"dropEffect"`
+	EffectAllowed string            `json:"effectAllowed"`
+	Types         []string          `json:"types"`
+	Files         []string          `json:"files"`
+	Data          map[string]string `json:"data"`
+}
+
+// DragEvent defines the payload of drag and drop events.
+type DragEvent struct {
+	MouseEvent
+
+	DataTransfer DataTransfer `json:"dataTransfer"`
+}
+
+// Touch defines a single point of contact of a TouchEvent.
+type Touch struct {
+	Identifier    int     `json:"identifier"`
+	Target        string  `json:"target"`
+	ScreenX       float64 `json:"screenX"`
+	ScreenY       float64 `json:"screenY"`
+	ClientX       float64 `json:"clientX"`
+	ClientY       float64 `json:"clientY"`
+	PageX         float64 `json:"pageX"`
+	PageY         float64 `json:"pageY"`
+	RadiusX       float64 `json:"radiusX"`
+	RadiusY       float64 `json:"radiusY"`
+	RotationAngle float64 `json:"rotationAngle"`
+	Force         float64 `json:"force"`
+}
+
+// TouchEvent defines the payload of touch events (e.g touchstart).
+type TouchEvent struct {
+	BaseEvent
+	ModifierKeys
+
+	Touches        []Touch `json:"touches"`
+	TargetTouches  []Touch `json:"targetTouches"`
+	ChangedTouches []Touch `json:"changedTouches"`
+}
+
+// WheelEvent defines the payload of wheel events.
+type WheelEvent struct {
+	MouseEvent
+
+	DeltaX    float64 `json:"deltaX"`
+	DeltaY    float64 `json:"deltaY"`
+	DeltaZ    float64 `json:"deltaZ"`
+	DeltaMode int     `json:"deltaMode"`
+}
+
+//==============================================================================
+
+// eventTypes maps lowercased event types to functions returning a new value
+// for decoding their payloads.
+var eventTypes = struct {
+	sync.RWMutex
+	makers map[string]func() EventObject
+}{
+	makers: make(map[string]func() EventObject),
+}
+
+func init() {
+	mouse := func() EventObject { return &MouseEvent{} }
+	keyboard := func() EventObject { return &KeyboardEvent{} }
+	input := func() EventObject { return &InputEvent{} }
+	focus := func() EventObject { return &FocusEvent{} }
+	drag := func() EventObject { return &DragEvent{} }
+	touch := func() EventObject { return &TouchEvent{} }
+
+	for _, name := range []string{"click", "dblclick", "auxclick", "contextmenu", "mousedown", "mouseup", "mousemove", "mouseenter", "mouseleave", "mouseover", "mouseout"} {
+		RegisterEventType(name, mouse)
+	}
+
+	for _, name := range []string{"keydown", "keyup", "keypress"} {
+		RegisterEventType(name, keyboard)
+	}
+
+	for _, name := range []string{"input", "beforeinput", "change"} {
+		RegisterEventType(name, input)
+	}
+
+	for _, name := range []string{"focus", "blur", "focusin", "focusout"} {
+		RegisterEventType(name, focus)
+	}
+
+	for _, name := range []string{"drag", "dragstart", "dragend", "dragenter", "dragleave", "dragover", "drop"} {
+		RegisterEventType(name, drag)
+	}
+
+	for _, name := range []string{"touchstart", "touchend", "touchmove", "touchcancel"} {
+		RegisterEventType(name, touch)
+	}
+
+	RegisterEventType("submit", func() EventObject { return &SubmitEvent{} })
+	RegisterEventType("wheel", func() EventObject { return &WheelEvent{} })
+}
+
+// RegisterEventType registers the function returning a new EventObject used
+// by DecodeEvent to decode payloads of the event type, replacing any previous
+// registration. Event types are matched case insensitively.
+func RegisterEventType(eventType string, maker func() EventObject) {
+	eventTypes.Lock()
+	defer eventTypes.Unlock()
+
+	eventTypes.makers[strings.ToLower(eventType)] = maker
+}
+
+// DecodeEvent decodes the json payload of a DOM event sent by a client into
+// the EventObject registered for its "type" field, using a *BaseEvent for
+// unregistered types. Values embedding BaseEvent keep the raw payload,
+// returned by Underlying.
+func DecodeEvent(payload []byte) (EventObject, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(payload, &head); err != nil {
+		return nil, err
+	}
+
+	return DecodeEventAs(head.Type, payload)
+}
+
+// DecodeEventAs decodes the json payload into the EventObject registered for
+// the giving event type, using a *BaseEvent for unregistered types.
+func DecodeEventAs(eventType string, payload []byte) (EventObject, error) {
+	eventTypes.RLock()
+	maker, ok := eventTypes.makers[strings.ToLower(eventType)]
+	eventTypes.RUnlock()
+
+	var ev EventObject = &BaseEvent{}
+	if ok {
+		ev = maker()
+	}
+
+	if err := json.Unmarshal(payload, ev); err != nil {
+		return nil, err
+	}
+
+	if based, ok := ev.(interface{ base() *BaseEvent }); ok {
+		base := based.base()
+		base.raw = append(json.RawMessage(nil), payload...)
+
+		if base.Type == "" {
+			base.Type = eventType
+		}
+	}
+
+	return ev, nil
+}
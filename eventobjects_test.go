@@ -0,0 +1,135 @@
+package trees_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+	"github.com/gu-io/trees/notifications"
+)
+
+func TestTypedEvents(t *testing.T) {
+	payload := []byte(`{"type":"click","target":"button[uid='1']","clientX":12.5,"button":1,"ctrlKey":true}`)
+
+	ev, err := trees.DecodeEvent(payload)
+	if err != nil {
+		t.Fatalf("\t%s\t  Should have decoded click payload: %+q", failed, err)
+	}
+
+	mouse, ok := ev.(*trees.MouseEvent)
+	if !ok || mouse.ClientX != 12.5 || mouse.Button != 1 || !mouse.CtrlKey || mouse.Target != "button[uid='1']" {
+		t.Fatalf("\t%s\t  Should have decoded a MouseEvent: %#v", failed, ev)
+	}
+
+	if raw, ok := mouse.Underlying().(json.RawMessage); !ok || string(raw) != string(payload) {
+		t.Fatalf("\t%s\t  Should have kept raw payload", failed)
+	}
+	t.Logf("\t%s\t  Should have decoded a MouseEvent", success)
+
+	cases := map[string]interface{}{
+		`{"type":"keydown","key":"Enter"}`:                        &trees.KeyboardEvent{},
+		`{"type":"input","value":"hello"}`:                        &trees.InputEvent{},
+		`{"type":"blur"}`:                                         &trees.FocusEvent{},
+		`{"type":"submit","values":{"name":["gu"]}}`:              &trees.SubmitEvent{},
+		`{"type":"drop","dataTransfer":{"types":["text/plain"]}}`: &trees.DragEvent{},
+		`{"type":"touchstart","touches":[{"identifier":1}]}`:      &trees.TouchEvent{},
+		`{"type":"wheel","deltaY":-3}`:                            &trees.WheelEvent{},
+		`{"type":"scroll"}`:                                       &trees.BaseEvent{},
+	}
+
+	for data, expected := range cases {
+		ev, err := trees.DecodeEvent([]byte(data))
+		if err != nil {
+			t.Fatalf("\t%s\t  Should have decoded %s: %+q", failed, data, err)
+		}
+
+		if got, want := typeName(ev), typeName(expected); got != want {
+			t.Fatalf("\t%s\t  Should have decoded %s as %s: %s", failed, data, want, got)
+		}
+	}
+	t.Logf("\t%s\t  Should have decoded all event payload types", success)
+
+	button := trees.NewMarkup("button", false)
+
+	var clicked *trees.MouseEvent
+	var keyed bool
+
+	click := events.ClickEvent(func(ev *trees.MouseEvent, root *trees.Markup) {
+		clicked = ev
+	})
+	click.Apply(button)
+	defer click.Remove.Remove()
+
+	key := events.KeyDownEvent(func(ev *trees.KeyboardEvent) {
+		keyed = true
+	})
+	key.Apply(button)
+	defer key.Remove.Remove()
+
+	notifications.Dispatch(trees.EventBroadcast{EventName: "ClickEvent", EventID: click.ID(), Event: mouse})
+	notifications.Dispatch(trees.EventBroadcast{EventName: "KeyDownEvent", EventID: key.ID(), Event: mouse})
+
+	if clicked != mouse || keyed {
+		t.Fatalf("\t%s\t  Should have called typed handlers for matching payloads only", failed)
+	}
+	t.Logf("\t%s\t  Should have called typed handlers for matching payloads only", success)
+
+	var moved []*trees.MouseEvent
+	var based int
+
+	handler := events.MakeHandler(func(ev *trees.MouseEvent) {
+		moved = append(moved, ev)
+	})
+	handler(&trees.DragEvent{MouseEvent: trees.MouseEvent{ClientX: 1}}, button)
+	handler(&trees.WheelEvent{MouseEvent: trees.MouseEvent{ClientX: 2}}, button)
+	handler(&trees.KeyboardEvent{}, button)
+
+	events.MakeHandler(func(ev *trees.BaseEvent, root *trees.Markup) {
+		based++
+	})(&trees.WheelEvent{}, button)
+
+	if len(moved) != 2 || moved[0].ClientX != 1 || moved[1].ClientX != 2 || based != 1 {
+		t.Fatalf("\t%s\t  Should have called typed handlers with embedded payloads: %d", failed, len(moved))
+	}
+	t.Logf("\t%s\t  Should have called typed handlers with embedded payloads", success)
+
+	events.DragStartEvent(func(ev *trees.MouseEvent) {})
+	events.ScrollEvent(func(ev *trees.KeyboardEvent) {})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("\t%s\t  Should have rejected keyboard handler for click events", failed)
+			}
+		}()
+
+		events.ClickEvent(func(ev *trees.KeyboardEvent) {})
+	}()
+	t.Logf("\t%s\t  Should have rejected typed handlers never receiving their payload", success)
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case *trees.MouseEvent:
+		return "MouseEvent"
+	case *trees.KeyboardEvent:
+		return "KeyboardEvent"
+	case *trees.InputEvent:
+		return "InputEvent"
+	case *trees.FocusEvent:
+		return "FocusEvent"
+	case *trees.SubmitEvent:
+		return "SubmitEvent"
+	case *trees.DragEvent:
+		return "DragEvent"
+	case *trees.TouchEvent:
+		return "TouchEvent"
+	case *trees.WheelEvent:
+		return "WheelEvent"
+	case *trees.BaseEvent:
+		return "BaseEvent"
+	}
+
+	return "unknown"
+}
@@ -3,6 +3,7 @@ package trees
 import (
 	"fmt"
 	"strings"
+	"time"
 	"github.com/gu-io/trees/notifications"
 )
 
@@ -53,16 +54,29 @@ func PreventDefault(state bool) EventOptions {
 }
 
 // Event provide a meta registry for helps in registering events for dom markups
-// which is translated to the nodes themselves
+// which is translated to the nodes themselves. The Handler of the event is
+// subscribed when the event is added to a markup and unsubscribed when the
+// markup is cleaned, emptied, removed or replaced.
 type Event struct {
 	Type                     string
 	PreventDefault           bool
 	StopPropagation          bool
 	UseCapture               bool
 	StopImmediatePropagation bool
+	Debounce                 time.Duration
+	Throttle                 time.Duration
+	Once                     bool
+	Passive                  bool
+	Keys                     []string
 	Tree                     *Markup
 	Remove                   notifications.Remover
+	Handler                  EventHandler
 	secTarget                string
+	dispatcher               *Dispatcher
+	scope                    *Dispatcher
+	scopeID                  string
+	middleware               []Middleware
+	limiter                  *eventLimiter
 }
 
 // NewEvent returns a event object that allows registering events to eventlisteners.
@@ -91,14 +105,20 @@ func (e *Event) Target() string {
 // EventJSON defines a struct which contains the giving events and
 // and tree of the giving tree.
 type EventJSON struct {
-	ParentSelector           string `json:"ParentSelector"`
-	EventSelector            string `json:"EventSelector"`
-	EventName                string `json:"EventName"`
-	Event                    string `json:"Event"`
-	PreventDefault           bool   `json:"PreventDefault"`
-	StopPropagation          bool   `json:"StopPropagation"`
-	UseCapture               bool   `json:"UseCapture"`
-	StopImmediatePropagation bool   `json:"StopImmediatePropagation"`
+	ParentSelector           string   `json:"ParentSelector"`
+	EventSelector            string   `json:"EventSelector"`
+	EventName                string   `json:"EventName"`
+	EventID                  string   `json:"EventID"`
+	Event                    string   `json:"Event"`
+	PreventDefault           bool     `json:"PreventDefault"`
+	StopPropagation          bool     `json:"StopPropagation"`
+	UseCapture               bool     `json:"UseCapture"`
+	StopImmediatePropagation bool     `json:"StopImmediatePropagation"`
+	Debounce                 int64    `json:"Debounce"`
+	Throttle                 int64    `json:"Throttle"`
+	Once                     bool     `json:"Once"`
+	Passive                  bool     `json:"Passive"`
+	Keys                     []string `json:"Keys"`
 }
 
 // EventJSON returns the event json structure which represent the giving event.
@@ -107,11 +127,17 @@ func (e *Event) EventJSON() EventJSON {
 		Event:                    e.Type,
 		UseCapture:               e.UseCapture,
 		EventName:                e.EventName(),
+		EventID:                  e.ID(),
 		EventSelector:            e.EventSelector(),
 		ParentSelector:           e.ParentEventSelector(),
 		PreventDefault:           e.PreventDefault,
 		StopPropagation:          e.StopPropagation,
 		StopImmediatePropagation: e.StopImmediatePropagation,
+		Debounce:                 int64(e.Debounce / time.Millisecond),
+		Throttle:                 int64(e.Throttle / time.Millisecond),
+		Once:                     e.Once,
+		Passive:                  e.Passive,
+		Keys:                     e.Keys,
 	}
 }
 
@@ -157,18 +183,105 @@ func (e *Event) Clone() *Event {
 		UseCapture:               e.UseCapture,
 		StopPropagation:          e.StopPropagation,
 		StopImmediatePropagation: e.StopImmediatePropagation,
+		Debounce:                 e.Debounce,
+		Throttle:                 e.Throttle,
+		Once:                     e.Once,
+		Passive:                  e.Passive,
+		Keys:                     append([]string(nil), e.Keys...),
+		Handler:                  e.Handler,
+		dispatcher:               e.dispatcher,
+		middleware:               append([]Middleware(nil), e.middleware...),
 	}
 }
 
 // Apply adds the event into the elements events lists
 func (e *Event) Apply(ex *Markup) {
-	if !ex.allowEvents {
+	if !ex.allowEvents || ex.frozen {
 		return
 	}
 
 	e.Tree = ex
 
 	ex.AddEvent(*e)
+
+	// keep the subscription made for the markup reachable from the event.
+	e.Remove = ex.events[len(ex.events)-1].Remove
+}
+
+// subscribe subscribes the handler of the event to the event broadcasts
+// matching the event's id, unless it has no handler or is subscribed.
+func (e *Event) subscribe() {
+	if e.Handler == nil || e.Remove != nil {
+		return
+	}
+
+	e.scope = e.scoped()
+
+	if e.scope != nil {
+		e.scopeID = e.ID()
+		e.Remove = e.scope.Subscribe(e.scopeID, func(evm EventBroadcast) {
+			e.handle(evm.Event, e.Tree)
+		})
+		return
+	}
+
+	e.Remove = notifications.SubscribeWithRemover(NewEventBroadcastHandler(func(evm EventBroadcast) {
+		if e.ID() != evm.EventID {
+			return
+		}
+
+		e.handle(evm.Event, e.Tree)
+	}))
+}
+
+// scoped returns the dispatcher the event must be subscribed with, if any.
+func (e *Event) scoped() *Dispatcher {
+	if e.dispatcher != nil {
+		return e.dispatcher
+	}
+
+	if e.Tree != nil {
+		return e.Tree.Dispatcher()
+	}
+
+	return nil
+}
+
+// stale returns true/false if the subscription of the event no longer matches
+// its dispatcher or id.
+func (e *Event) stale() bool {
+	if e.Remove == nil {
+		return false
+	}
+
+	scope := e.scoped()
+	return scope != e.scope || (scope != nil && e.scopeID != e.ID())
+}
+
+// unsubscribe removes the subscription of the event, stopping its pending
+// debounced call.
+func (e *Event) unsubscribe() {
+	if e.Remove == nil {
+		return
+	}
+
+	e.Remove.Remove()
+	e.Remove = nil
+
+	if e.limiter != nil {
+		e.limiter.stop()
+	}
+}
+
+// resubscribe replaces the subscription of the event with one matching its
+// current dispatcher and id, keeping the state of its limits.
+func (e *Event) resubscribe() {
+	if e.Remove != nil {
+		e.Remove.Remove()
+		e.Remove = nil
+	}
+
+	e.subscribe()
 }
 
 // String returns the string representation of the giving event.
@@ -8,12 +8,14 @@
 package events
 
 import (
-	"github.com/gu-io/trees/notifications"
 	"github.com/gu-io/trees"
 )
 
 // EventHandler defines a function type for event callbacks.
-type EventHandler func(trees.EventObject, *trees.Markup)
+type EventHandler = trees.EventHandler
+
+// Middleware defines a function type which wraps a EventHandler.
+type Middleware = trees.Middleware
 
 // WrapHandler wraps the function returning a EventHandler to call the provided
 // function to be called when the event occurs without need for the arguments.
@@ -38,32 +40,12 @@ func WrapEventOnlyHandler(callback func(trees.EventObject)) EventHandler {
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AbortEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("abort", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("abort")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -75,32 +57,12 @@ func AbortEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AfterPrintEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AfterPrint", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AfterPrint")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -112,32 +74,12 @@ func AfterPrintEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AfterScriptExecuteEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AfterScriptExecute", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AfterScriptExecute")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -149,32 +91,12 @@ func AfterScriptExecuteEvent(callback interface{}, options ...trees.EventOptions
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AlertActiveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AlertActive", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AlertActive")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -186,32 +108,12 @@ func AlertActiveEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AlertCloseEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AlertClose", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AlertClose")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -223,32 +125,12 @@ func AlertCloseEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AlertingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("alerting", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("alerting")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -260,32 +142,12 @@ func AlertingEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AnimationEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AnimationEnd", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AnimationEnd")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -297,32 +159,12 @@ func AnimationEndEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AnimationIterationEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AnimationIteration", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AnimationIteration")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -334,32 +176,12 @@ func AnimationIterationEvent(callback interface{}, options ...trees.EventOptions
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AnimationStartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AnimationStart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AnimationStart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -371,32 +193,12 @@ func AnimationStartEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AppinstalledEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("appinstalled", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("appinstalled")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -408,32 +210,12 @@ func AppinstalledEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AudioProcessEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("AudioProcess", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("AudioProcess")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -445,32 +227,12 @@ func AudioProcessEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AudioendEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("audioend", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("audioend")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -482,32 +244,12 @@ func AudioendEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AudiostartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("audiostart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("audiostart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -519,32 +261,12 @@ func AudiostartEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func AuxclickEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("auxclick", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("auxclick")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -556,32 +278,12 @@ func AuxclickEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BeforeInstallPromptEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("BeforeInstallPrompt", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("BeforeInstallPrompt")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -593,32 +295,12 @@ func BeforeInstallPromptEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BeforePrintEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("BeforePrint", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("BeforePrint")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -630,32 +312,12 @@ func BeforePrintEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BeforeScriptExecuteEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("BeforeScriptExecute", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("BeforeScriptExecute")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -667,32 +329,12 @@ func BeforeScriptExecuteEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BeforeUnloadEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("BeforeUnload", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("BeforeUnload")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -704,32 +346,12 @@ func BeforeUnloadEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BeginEventEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("beginEvent", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("beginEvent")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -741,32 +363,12 @@ func BeginEventEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BlockedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("blocked", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("blocked")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -778,32 +380,12 @@ func BlockedEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BlurEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("blur", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("blur")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -815,32 +397,12 @@ func BlurEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BoundaryEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("boundary", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("boundary")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -852,32 +414,12 @@ func BoundaryEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BroadcastEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("broadcast", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("broadcast")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -889,32 +431,12 @@ func BroadcastEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func BusyEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("busy", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("busy")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -926,32 +448,12 @@ func BusyEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CSSRuleViewCSSLinkClickedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CSSRuleViewCSSLinkClicked", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CSSRuleViewCSSLinkClicked")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -963,32 +465,12 @@ func CSSRuleViewCSSLinkClickedEvent(callback interface{}, options ...trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CSSRuleViewChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CSSRuleViewChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CSSRuleViewChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1000,32 +482,12 @@ func CSSRuleViewChangeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CSSRuleViewRefreshedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CSSRuleViewRefreshed", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CSSRuleViewRefreshed")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1037,32 +499,12 @@ func CSSRuleViewRefreshedEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CachedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("cached", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("cached")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1074,32 +516,12 @@ func CachedEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CallschangedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("callschanged", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("callschanged")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1111,32 +533,12 @@ func CallschangedEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CanPlayEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CanPlay", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CanPlay")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1148,32 +550,12 @@ func CanPlayEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CanPlayThroughEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CanPlayThrough", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CanPlayThrough")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1185,32 +567,12 @@ func CanPlayThroughEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CardstatechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("cardstatechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("cardstatechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1222,32 +584,12 @@ func CardstatechangeEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CfstatechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("cfstatechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("cfstatechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1259,32 +601,12 @@ func CfstatechangeEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("change", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("change")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1296,32 +618,12 @@ func ChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ChargingChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("ChargingChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("ChargingChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1333,32 +635,12 @@ func ChargingChangeEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ChargingTimeChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("ChargingTimeChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("ChargingTimeChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1370,32 +652,12 @@ func ChargingTimeChangeEvent(callback interface{}, options ...trees.EventOptions
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CheckboxStateChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CheckboxStateChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CheckboxStateChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1407,32 +669,12 @@ func CheckboxStateChangeEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CheckingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("checking", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("checking")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1444,32 +686,12 @@ func CheckingEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ClickEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("click", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("click")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1481,32 +703,12 @@ func ClickEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CloseEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("close", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("close")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1518,32 +720,12 @@ func CloseEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CommandEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("command", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("command")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1555,32 +737,12 @@ func CommandEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CommandupdateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("commandupdate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("commandupdate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1592,32 +754,12 @@ func CommandupdateEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CompleteEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("complete", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("complete")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1629,32 +771,12 @@ func CompleteEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CompositionEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CompositionEnd", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CompositionEnd")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1666,32 +788,12 @@ func CompositionEndEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CompositionStartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CompositionStart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CompositionStart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1703,32 +805,12 @@ func CompositionStartEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CompositionUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("CompositionUpdate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("CompositionUpdate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1740,32 +822,12 @@ func CompositionUpdateEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ConnectingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("connecting", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("connecting")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1777,32 +839,12 @@ func ConnectingEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ConnectionInfoUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("connectionInfoUpdate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("connectionInfoUpdate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1814,32 +856,12 @@ func ConnectionInfoUpdateEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ContextMenuEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("ContextMenu", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("ContextMenu")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1851,32 +873,12 @@ func ContextMenuEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CopyEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("copy", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("copy")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1888,32 +890,12 @@ func CopyEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func CutEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("cut", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("cut")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1925,32 +907,12 @@ func CutEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMAutoCompleteEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMAutoComplete", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMAutoComplete")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1962,32 +924,12 @@ func DOMAutoCompleteEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMContentLoadedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMContentLoaded", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMContentLoaded")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -1999,32 +941,12 @@ func DOMContentLoadedEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMFrameContentLoadedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMFrameContentLoaded", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMFrameContentLoaded")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2036,32 +958,12 @@ func DOMFrameContentLoadedEvent(callback interface{}, options ...trees.EventOpti
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMLinkAddedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMLinkAdded", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMLinkAdded")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2073,32 +975,12 @@ func DOMLinkAddedEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMLinkRemovedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMLinkRemoved", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMLinkRemoved")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2110,32 +992,12 @@ func DOMLinkRemovedEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMMenuItemActiveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMMenuItemActive", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMMenuItemActive")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2147,32 +1009,12 @@ func DOMMenuItemActiveEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMMenuItemInactiveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMMenuItemInactive", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMMenuItemInactive")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2184,32 +1026,12 @@ func DOMMenuItemInactiveEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMMetaAddedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMMetaAdded", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMMetaAdded")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2221,32 +1043,12 @@ func DOMMetaAddedEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMMetaRemovedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMMetaRemoved", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMMetaRemoved")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2258,32 +1060,12 @@ func DOMMetaRemovedEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMModalDialogClosedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMModalDialogClosed", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMModalDialogClosed")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2295,32 +1077,12 @@ func DOMModalDialogClosedEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMPopupBlockedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMPopupBlocked", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMPopupBlocked")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2332,32 +1094,12 @@ func DOMPopupBlockedEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMTitleChangedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMTitleChanged", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMTitleChanged")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2369,35 +1111,15 @@ func DOMTitleChangedEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMWillOpenModalDialogEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMWillOpenModalDialog", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMWillOpenModalDialog")}, options...)
 
 	ev := trees.NewEvent(ops...)
+	ev.Handler = handler
 
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
-
-	return ev
-}
+	return ev
+}
 
 // DOMWindowCloseEvent Documentation is as below: "A window is about to be closed."
 // https://developer.mozilla.org/docs/Web/Reference/Events/DOMWindowClose
@@ -2406,32 +1128,12 @@ func DOMWillOpenModalDialogEvent(callback interface{}, options ...trees.EventOpt
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMWindowCloseEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMWindowClose", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMWindowClose")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2443,32 +1145,12 @@ func DOMWindowCloseEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DOMWindowCreatedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DOMWindowCreated", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DOMWindowCreated")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2480,32 +1162,12 @@ func DOMWindowCreatedEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DatachangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("datachange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("datachange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2517,32 +1179,12 @@ func DatachangeEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DataerrorEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("dataerror", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("dataerror")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2554,32 +1196,12 @@ func DataerrorEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DblClickEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DblClick", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DblClick")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2591,32 +1213,12 @@ func DblClickEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DeliveredEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("delivered", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("delivered")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2628,32 +1230,12 @@ func DeliveredEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DeviceLightEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DeviceLight", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DeviceLight")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2665,32 +1247,12 @@ func DeviceLightEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DeviceMotionEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DeviceMotion", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DeviceMotion")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2702,32 +1264,12 @@ func DeviceMotionEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DeviceOrientationEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DeviceOrientation", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DeviceOrientation")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2739,32 +1281,12 @@ func DeviceOrientationEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DeviceProximityEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DeviceProximity", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DeviceProximity")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2776,32 +1298,12 @@ func DeviceProximityEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DevicechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("devicechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("devicechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2813,32 +1315,12 @@ func DevicechangeEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DialingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("dialing", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("dialing")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2850,32 +1332,12 @@ func DialingEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DisabledEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("disabled", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("disabled")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2887,32 +1349,12 @@ func DisabledEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DischargingTimeChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DischargingTimeChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DischargingTimeChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2924,32 +1366,12 @@ func DischargingTimeChangeEvent(callback interface{}, options ...trees.EventOpti
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DisconnectedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("disconnected", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("disconnected")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2961,32 +1383,12 @@ func DisconnectedEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DisconnectingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("disconnecting", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("disconnecting")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -2998,32 +1400,12 @@ func DisconnectingEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DownloadingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("downloading", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("downloading")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3035,32 +1417,12 @@ func DownloadingEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DragEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("drag", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("drag")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3072,32 +1434,12 @@ func DragEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DragEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DragEnd", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DragEnd")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3109,32 +1451,12 @@ func DragEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DragEnterEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DragEnter", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DragEnter")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3146,32 +1468,12 @@ func DragEnterEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DragLeaveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DragLeave", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DragLeave")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3183,32 +1485,12 @@ func DragLeaveEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DragOverEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DragOver", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DragOver")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3220,32 +1502,12 @@ func DragOverEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DragStartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DragStart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DragStart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3257,32 +1519,12 @@ func DragStartEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DropEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("drop", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("drop")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3294,32 +1536,12 @@ func DropEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func DurationChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("DurationChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("DurationChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3331,32 +1553,12 @@ func DurationChangeEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func EmptiedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("emptied", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("emptied")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3368,32 +1570,12 @@ func EmptiedEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func EnabledEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("enabled", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("enabled")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3405,32 +1587,12 @@ func EnabledEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func EndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("end", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("end")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3442,32 +1604,12 @@ func EndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func EndEventEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("endEvent", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("endEvent")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3479,32 +1621,12 @@ func EndEventEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func EndedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("ended", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("ended")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3516,32 +1638,12 @@ func EndedEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func FocusEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("focus", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("focus")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3553,32 +1655,12 @@ func FocusEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func FocusInEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("FocusIn", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("FocusIn")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3590,32 +1672,12 @@ func FocusInEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func FocusOutEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("FocusOut", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("FocusOut")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3627,32 +1689,12 @@ func FocusOutEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func FullScreenChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("FullScreenChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("FullScreenChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3664,32 +1706,12 @@ func FullScreenChangeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func FullScreenErrorEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("FullScreenError", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("FullScreenError")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3701,32 +1723,12 @@ func FullScreenErrorEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func FullscreenEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("fullscreen", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("fullscreen")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3738,32 +1740,12 @@ func FullscreenEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func GamepadConnectedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("GamepadConnected", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("GamepadConnected")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3775,32 +1757,12 @@ func GamepadConnectedEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func GamepadDisconnectedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("GamepadDisconnected", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("GamepadDisconnected")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3812,32 +1774,12 @@ func GamepadDisconnectedEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func GotpointercaptureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("gotpointercapture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("gotpointercapture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3849,32 +1791,12 @@ func GotpointercaptureEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func HashChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("HashChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("HashChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3886,32 +1808,12 @@ func HashChangeEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func HeldEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("held", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("held")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3923,32 +1825,12 @@ func HeldEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func HoldingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("holding", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("holding")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3960,32 +1842,12 @@ func HoldingEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func IcccardlockerrorEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("icccardlockerror", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("icccardlockerror")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -3997,32 +1859,12 @@ func IcccardlockerrorEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func IccinfochangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("iccinfochange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("iccinfochange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4034,32 +1876,12 @@ func IccinfochangeEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func IncomingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("incoming", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("incoming")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4071,32 +1893,12 @@ func IncomingEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func InputEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("input", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("input")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4108,32 +1910,12 @@ func InputEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func InvalidEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("invalid", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("invalid")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4145,32 +1927,12 @@ func InvalidEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func KeyDownEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("KeyDown", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("KeyDown")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4182,32 +1944,12 @@ func KeyDownEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func KeyPressEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("KeyPress", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("KeyPress")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4219,32 +1961,12 @@ func KeyPressEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func KeyUpEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("KeyUp", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("KeyUp")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4256,32 +1978,12 @@ func KeyUpEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LanguageChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("LanguageChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("LanguageChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4293,32 +1995,12 @@ func LanguageChangeEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LevelChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("LevelChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("LevelChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4330,32 +2012,12 @@ func LevelChangeEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LoadEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("load", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("load")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4367,32 +2029,12 @@ func LoadEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LoadEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("LoadEnd", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("LoadEnd")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4404,32 +2046,12 @@ func LoadEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LoadStartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("LoadStart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("LoadStart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4441,32 +2063,12 @@ func LoadStartEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LoadedDataEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("LoadedData", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("LoadedData")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4478,32 +2080,12 @@ func LoadedDataEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LoadedMetadataEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("LoadedMetadata", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("LoadedMetadata")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4515,32 +2097,12 @@ func LoadedMetadataEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LocalizedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("localized", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("localized")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4552,32 +2114,12 @@ func LocalizedEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func LostpointercaptureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("lostpointercapture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("lostpointercapture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4589,32 +2131,12 @@ func LostpointercaptureEvent(callback interface{}, options ...trees.EventOptions
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MarkEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mark", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mark")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4626,32 +2148,12 @@ func MarkEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MessageEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("message", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("message")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4663,32 +2165,12 @@ func MessageEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MouseDownEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MouseDown", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MouseDown")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4700,32 +2182,12 @@ func MouseDownEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MouseEnterEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MouseEnter", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MouseEnter")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4737,32 +2199,12 @@ func MouseEnterEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MouseLeaveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MouseLeave", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MouseLeave")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4774,32 +2216,12 @@ func MouseLeaveEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MouseMoveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MouseMove", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MouseMove")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4811,32 +2233,12 @@ func MouseMoveEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MouseOutEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MouseOut", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MouseOut")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4848,32 +2250,12 @@ func MouseOutEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MouseOverEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MouseOver", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MouseOver")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4885,32 +2267,12 @@ func MouseOverEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MouseUpEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MouseUp", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MouseUp")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4922,32 +2284,12 @@ func MouseUpEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozAfterPaintEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozAfterPaint", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozAfterPaint")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4959,32 +2301,12 @@ func MozAfterPaintEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozAudioAvailableEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozAudioAvailable", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozAudioAvailable")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -4996,32 +2318,12 @@ func MozAudioAvailableEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozBeforeResizeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozBeforeResize", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozBeforeResize")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5033,32 +2335,12 @@ func MozBeforeResizeEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozEdgeUIGestureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozEdgeUIGesture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozEdgeUIGesture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5070,32 +2352,12 @@ func MozEdgeUIGestureEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozEnteredDomFullscreenEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozEnteredDomFullscreen", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozEnteredDomFullscreen")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5107,32 +2369,12 @@ func MozEnteredDomFullscreenEvent(callback interface{}, options ...trees.EventOp
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozGamepadButtonDownEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozGamepadButtonDown", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozGamepadButtonDown")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5144,32 +2386,12 @@ func MozGamepadButtonDownEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozGamepadButtonUpEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozGamepadButtonUp", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozGamepadButtonUp")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5181,32 +2403,12 @@ func MozGamepadButtonUpEvent(callback interface{}, options ...trees.EventOptions
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozMagnifyGestureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozMagnifyGesture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozMagnifyGesture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5218,32 +2420,12 @@ func MozMagnifyGestureEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozMagnifyGestureStartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozMagnifyGestureStart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozMagnifyGestureStart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5255,32 +2437,12 @@ func MozMagnifyGestureStartEvent(callback interface{}, options ...trees.EventOpt
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozMagnifyGestureUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozMagnifyGestureUpdate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozMagnifyGestureUpdate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5292,32 +2454,12 @@ func MozMagnifyGestureUpdateEvent(callback interface{}, options ...trees.EventOp
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozPressTapGestureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozPressTapGesture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozPressTapGesture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5329,32 +2471,12 @@ func MozPressTapGestureEvent(callback interface{}, options ...trees.EventOptions
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozRotateGestureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozRotateGesture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozRotateGesture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5366,32 +2488,12 @@ func MozRotateGestureEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozRotateGestureStartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozRotateGestureStart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozRotateGestureStart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5403,32 +2505,12 @@ func MozRotateGestureStartEvent(callback interface{}, options ...trees.EventOpti
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozRotateGestureUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozRotateGestureUpdate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozRotateGestureUpdate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5440,32 +2522,12 @@ func MozRotateGestureUpdateEvent(callback interface{}, options ...trees.EventOpt
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozScrolledAreaChangedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozScrolledAreaChanged", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozScrolledAreaChanged")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5477,32 +2539,12 @@ func MozScrolledAreaChangedEvent(callback interface{}, options ...trees.EventOpt
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozSwipeGestureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozSwipeGesture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozSwipeGesture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5514,32 +2556,12 @@ func MozSwipeGestureEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozTapGestureEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("MozTapGesture", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("MozTapGesture")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5551,32 +2573,12 @@ func MozTapGestureEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowseractivitydoneEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowseractivitydone", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowseractivitydone")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5588,32 +2590,12 @@ func MozbrowseractivitydoneEvent(callback interface{}, options ...trees.EventOpt
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserasyncscrollEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserasyncscroll", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserasyncscroll")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5625,32 +2607,12 @@ func MozbrowserasyncscrollEvent(callback interface{}, options ...trees.EventOpti
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowseraudioplaybackchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowseraudioplaybackchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowseraudioplaybackchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5662,32 +2624,12 @@ func MozbrowseraudioplaybackchangeEvent(callback interface{}, options ...trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsercaretstatechangedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsercaretstatechanged", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsercaretstatechanged")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5699,32 +2641,12 @@ func MozbrowsercaretstatechangedEvent(callback interface{}, options ...trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsercloseEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserclose", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserclose")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5736,32 +2658,12 @@ func MozbrowsercloseEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsercontextmenuEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsercontextmenu", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsercontextmenu")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5773,32 +2675,12 @@ func MozbrowsercontextmenuEvent(callback interface{}, options ...trees.EventOpti
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserdocumentfirstpaintEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserdocumentfirstpaint", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserdocumentfirstpaint")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5810,32 +2692,12 @@ func MozbrowserdocumentfirstpaintEvent(callback interface{}, options ...trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsererrorEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsererror", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsererror")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5847,32 +2709,12 @@ func MozbrowsererrorEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserfindchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserfindchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserfindchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5884,32 +2726,12 @@ func MozbrowserfindchangeEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserfirstpaintEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserfirstpaint", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserfirstpaint")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5921,32 +2743,12 @@ func MozbrowserfirstpaintEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsericonchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsericonchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsericonchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5958,32 +2760,12 @@ func MozbrowsericonchangeEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserloadendEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserloadend", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserloadend")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -5995,32 +2777,12 @@ func MozbrowserloadendEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserloadstartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserloadstart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserloadstart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6032,32 +2794,12 @@ func MozbrowserloadstartEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserlocationchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserlocationchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserlocationchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6069,32 +2811,12 @@ func MozbrowserlocationchangeEvent(callback interface{}, options ...trees.EventO
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsermanifestchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsermanifestchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsermanifestchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6106,32 +2828,12 @@ func MozbrowsermanifestchangeEvent(callback interface{}, options ...trees.EventO
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsermetachangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsermetachange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsermetachange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6143,32 +2845,12 @@ func MozbrowsermetachangeEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowseropensearchEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowseropensearch", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowseropensearch")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6180,32 +2862,12 @@ func MozbrowseropensearchEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowseropentabEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowseropentab", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowseropentab")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6217,32 +2879,12 @@ func MozbrowseropentabEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowseropenwindowEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowseropenwindow", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowseropenwindow")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6254,32 +2896,12 @@ func MozbrowseropenwindowEvent(callback interface{}, options ...trees.EventOptio
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserresizeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserresize", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserresize")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6291,32 +2913,12 @@ func MozbrowserresizeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserscrollEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserscroll", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserscroll")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6328,32 +2930,12 @@ func MozbrowserscrollEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserscrollareachangedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserscrollareachanged", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserscrollareachanged")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6365,32 +2947,12 @@ func MozbrowserscrollareachangedEvent(callback interface{}, options ...trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserscrollviewchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserscrollviewchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserscrollviewchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6402,32 +2964,12 @@ func MozbrowserscrollviewchangeEvent(callback interface{}, options ...trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsersecuritychangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsersecuritychange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsersecuritychange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6439,32 +2981,12 @@ func MozbrowsersecuritychangeEvent(callback interface{}, options ...trees.EventO
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserselectionstatechangedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserselectionstatechanged", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserselectionstatechanged")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6476,32 +2998,12 @@ func MozbrowserselectionstatechangedEvent(callback interface{}, options ...trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsershowmodalpromptEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsershowmodalprompt", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsershowmodalprompt")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6513,32 +3015,12 @@ func MozbrowsershowmodalpromptEvent(callback interface{}, options ...trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowsertitlechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowsertitlechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowsertitlechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6550,32 +3032,12 @@ func MozbrowsertitlechangeEvent(callback interface{}, options ...trees.EventOpti
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowserusernameandpasswordrequiredEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowserusernameandpasswordrequired", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowserusernameandpasswordrequired")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6587,32 +3049,12 @@ func MozbrowserusernameandpasswordrequiredEvent(callback interface{}, options ..
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MozbrowservisibilitychangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("mozbrowservisibilitychange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("mozbrowservisibilitychange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6624,32 +3066,12 @@ func MozbrowservisibilitychangeEvent(callback interface{}, options ...trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func MoztimechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("moztimechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("moztimechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6661,32 +3083,12 @@ func MoztimechangeEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func NoUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("NoUpdate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("NoUpdate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6698,32 +3100,12 @@ func NoUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func NomatchEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("nomatch", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("nomatch")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6735,32 +3117,12 @@ func NomatchEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func NotificationclickEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("notificationclick", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("notificationclick")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6772,32 +3134,12 @@ func NotificationclickEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ObsoleteEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("obsolete", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("obsolete")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6809,32 +3151,12 @@ func ObsoleteEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func OfflineEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("offline", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("offline")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6846,32 +3168,12 @@ func OfflineEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func OnconnectedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("onconnected", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("onconnected")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6883,32 +3185,12 @@ func OnconnectedEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func OnlineEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("online", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("online")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6920,32 +3202,12 @@ func OnlineEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func OpenEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("open", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("open")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6957,32 +3219,12 @@ func OpenEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func OrientationChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("OrientationChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("OrientationChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -6994,32 +3236,12 @@ func OrientationChangeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func OverflowEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("overflow", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("overflow")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7031,32 +3253,12 @@ func OverflowEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PageHideEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("PageHide", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("PageHide")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7068,32 +3270,12 @@ func PageHideEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PageShowEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("PageShow", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("PageShow")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7105,32 +3287,12 @@ func PageShowEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PasteEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("paste", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("paste")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7142,32 +3304,12 @@ func PasteEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PauseEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pause", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pause")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7179,32 +3321,12 @@ func PauseEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PlayEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("play", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("play")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7216,32 +3338,12 @@ func PlayEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PlayingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("playing", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("playing")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7253,32 +3355,12 @@ func PlayingEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointerLockChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("PointerLockChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("PointerLockChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7290,32 +3372,12 @@ func PointerLockChangeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointerLockErrorEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("PointerLockError", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("PointerLockError")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7327,32 +3389,12 @@ func PointerLockErrorEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointercancelEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointercancel", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointercancel")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7364,32 +3406,12 @@ func PointercancelEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointerdownEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointerdown", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointerdown")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7401,32 +3423,12 @@ func PointerdownEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointerenterEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointerenter", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointerenter")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7438,32 +3440,12 @@ func PointerenterEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointerleaveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointerleave", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointerleave")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7475,32 +3457,12 @@ func PointerleaveEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointermoveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointermove", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointermove")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7512,32 +3474,12 @@ func PointermoveEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointeroutEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointerout", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointerout")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7549,32 +3491,12 @@ func PointeroutEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointeroverEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointerover", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointerover")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7586,32 +3508,12 @@ func PointeroverEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PointerupEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pointerup", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pointerup")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7623,32 +3525,12 @@ func PointerupEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PopStateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("PopState", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("PopState")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7660,32 +3542,12 @@ func PopStateEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PopuphiddenEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("popuphidden", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("popuphidden")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7697,32 +3559,12 @@ func PopuphiddenEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PopuphidingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("popuphiding", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("popuphiding")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7734,32 +3576,12 @@ func PopuphidingEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PopupshowingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("popupshowing", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("popupshowing")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7771,32 +3593,12 @@ func PopupshowingEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PopupshownEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("popupshown", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("popupshown")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7808,32 +3610,12 @@ func PopupshownEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ProgressEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("progress", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("progress")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7845,32 +3627,12 @@ func ProgressEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PushEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("push", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("push")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7882,32 +3644,12 @@ func PushEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func PushsubscriptionchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("pushsubscriptionchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("pushsubscriptionchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7919,32 +3661,12 @@ func PushsubscriptionchangeEvent(callback interface{}, options ...trees.EventOpt
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func RadioStateChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("RadioStateChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("RadioStateChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7956,32 +3678,12 @@ func RadioStateChangeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func RateChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("RateChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("RateChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -7993,32 +3695,12 @@ func RateChangeEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ReadystateChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("ReadystateChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("ReadystateChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8030,32 +3712,12 @@ func ReadystateChangeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ReceivedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("received", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("received")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8067,32 +3729,12 @@ func ReceivedEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func RepeatEventEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("repeatEvent", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("repeatEvent")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8104,32 +3746,12 @@ func RepeatEventEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func RequestprogressEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("requestprogress", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("requestprogress")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8141,32 +3763,12 @@ func RequestprogressEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ResetEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("reset", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("reset")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8178,32 +3780,12 @@ func ResetEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ResizeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("resize", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("resize")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8215,32 +3797,12 @@ func ResizeEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ResourcetimingbufferfullEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("resourcetimingbufferfull", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("resourcetimingbufferfull")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8252,32 +3814,12 @@ func ResourcetimingbufferfullEvent(callback interface{}, options ...trees.EventO
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ResponseprogressEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("responseprogress", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("responseprogress")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8289,32 +3831,12 @@ func ResponseprogressEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ResultEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("result", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("result")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8326,32 +3848,12 @@ func ResultEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ResumeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("resume", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("resume")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8363,32 +3865,12 @@ func ResumeEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ResumingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("resuming", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("resuming")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8400,32 +3882,12 @@ func ResumingEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SSTabClosingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SSTabClosing", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SSTabClosing")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8437,32 +3899,12 @@ func SSTabClosingEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SSTabRestoredEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SSTabRestored", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SSTabRestored")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8474,32 +3916,12 @@ func SSTabRestoredEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SSTabRestoringEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SSTabRestoring", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SSTabRestoring")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8511,32 +3933,12 @@ func SSTabRestoringEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SSWindowClosingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SSWindowClosing", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SSWindowClosing")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8548,32 +3950,12 @@ func SSWindowClosingEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SSWindowStateBusyEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SSWindowStateBusy", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SSWindowStateBusy")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8585,32 +3967,12 @@ func SSWindowStateBusyEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SSWindowStateReadyEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SSWindowStateReady", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SSWindowStateReady")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8622,32 +3984,12 @@ func SSWindowStateReadyEvent(callback interface{}, options ...trees.EventOptions
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SVGAbortEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SVGAbort", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SVGAbort")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8659,32 +4001,12 @@ func SVGAbortEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SVGErrorEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SVGError", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SVGError")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8696,32 +4018,12 @@ func SVGErrorEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SVGLoadEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SVGLoad", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SVGLoad")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8733,32 +4035,12 @@ func SVGLoadEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SVGResizeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SVGResize", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SVGResize")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8770,32 +4052,12 @@ func SVGResizeEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SVGScrollEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SVGScroll", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SVGScroll")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8807,32 +4069,12 @@ func SVGScrollEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SVGUnloadEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SVGUnload", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SVGUnload")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8844,32 +4086,12 @@ func SVGUnloadEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SVGZoomEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SVGZoom", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SVGZoom")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8881,32 +4103,12 @@ func SVGZoomEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ScrollEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("scroll", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("scroll")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8918,32 +4120,12 @@ func ScrollEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SeekedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("seeked", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("seeked")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8955,32 +4137,12 @@ func SeekedEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SeekingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("seeking", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("seeking")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -8992,32 +4154,12 @@ func SeekingEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SelectEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("select", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("select")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9029,32 +4171,12 @@ func SelectEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SelectionchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("selectionchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("selectionchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9066,32 +4188,12 @@ func SelectionchangeEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SelectstartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("selectstart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("selectstart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9103,32 +4205,12 @@ func SelectstartEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SentEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("sent", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("sent")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9140,32 +4222,12 @@ func SentEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ShowEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("show", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("show")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9177,32 +4239,12 @@ func ShowEvent(callback interface{}, options ...trees.EventOptions) *trees.Event
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SizemodechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("sizemodechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("sizemodechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9214,32 +4256,12 @@ func SizemodechangeEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SmartCardInsertEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SmartCardInsert", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SmartCardInsert")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9251,32 +4273,12 @@ func SmartCardInsertEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SmartCardRemoveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("SmartCardRemove", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("SmartCardRemove")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9288,32 +4290,12 @@ func SmartCardRemoveEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SoundendEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("soundend", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("soundend")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9325,32 +4307,12 @@ func SoundendEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SoundstartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("soundstart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("soundstart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9362,32 +4324,12 @@ func SoundstartEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SpeechendEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("speechend", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("speechend")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9399,32 +4341,12 @@ func SpeechendEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SpeechstartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("speechstart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("speechstart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9436,32 +4358,12 @@ func SpeechstartEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func StalledEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("stalled", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("stalled")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9473,32 +4375,12 @@ func StalledEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func StartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("start", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("start")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9510,32 +4392,12 @@ func StartEvent(callback interface{}, options ...trees.EventOptions) *trees.Even
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func StatechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("statechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("statechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9547,32 +4409,12 @@ func StatechangeEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func StatuschangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("statuschange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("statuschange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9584,32 +4426,12 @@ func StatuschangeEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func StkcommandEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("stkcommand", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("stkcommand")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9621,32 +4443,12 @@ func StkcommandEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func StksessionendEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("stksessionend", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("stksessionend")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9658,32 +4460,12 @@ func StksessionendEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func StorageEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("storage", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("storage")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9695,32 +4477,12 @@ func StorageEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SubmitEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("submit", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("submit")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9732,32 +4494,12 @@ func SubmitEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SuccessEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("success", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("success")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9769,32 +4511,12 @@ func SuccessEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func SuspendEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("suspend", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("suspend")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9806,32 +4528,12 @@ func SuspendEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TabCloseEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TabClose", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TabClose")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9843,32 +4545,12 @@ func TabCloseEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TabHideEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TabHide", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TabHide")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9880,32 +4562,12 @@ func TabHideEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TabOpenEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TabOpen", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TabOpen")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9917,32 +4579,12 @@ func TabOpenEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TabPinnedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TabPinned", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TabPinned")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9954,32 +4596,12 @@ func TabPinnedEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TabSelectEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TabSelect", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TabSelect")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -9991,32 +4613,12 @@ func TabSelectEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TabShowEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TabShow", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TabShow")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10028,32 +4630,12 @@ func TabShowEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TabUnpinnedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TabUnpinned", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TabUnpinned")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10065,32 +4647,12 @@ func TabUnpinnedEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TimeUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TimeUpdate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TimeUpdate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10102,32 +4664,12 @@ func TimeUpdateEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TimeoutEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("timeout", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("timeout")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10139,32 +4681,12 @@ func TimeoutEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TouchCancelEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TouchCancel", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TouchCancel")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10176,32 +4698,12 @@ func TouchCancelEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TouchEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TouchEnd", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TouchEnd")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10213,32 +4715,12 @@ func TouchEndEvent(callback interface{}, options ...trees.EventOptions) *trees.E
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TouchEnterEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TouchEnter", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TouchEnter")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10250,32 +4732,12 @@ func TouchEnterEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TouchLeaveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TouchLeave", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TouchLeave")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10287,32 +4749,12 @@ func TouchLeaveEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TouchMoveEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TouchMove", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TouchMove")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10324,32 +4766,12 @@ func TouchMoveEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TouchStartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TouchStart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TouchStart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10361,32 +4783,12 @@ func TouchStartEvent(callback interface{}, options ...trees.EventOptions) *trees
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TransitionEndEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("TransitionEnd", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("TransitionEnd")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10398,32 +4800,12 @@ func TransitionEndEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TransitioncancelEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("transitioncancel", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("transitioncancel")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10435,32 +4817,12 @@ func TransitioncancelEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TransitionrunEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("transitionrun", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("transitionrun")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10472,32 +4834,12 @@ func TransitionrunEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func TransitionstartEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("transitionstart", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("transitionstart")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10509,32 +4851,12 @@ func TransitionstartEvent(callback interface{}, options ...trees.EventOptions) *
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func UnderflowEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("underflow", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("underflow")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10546,32 +4868,12 @@ func UnderflowEvent(callback interface{}, options ...trees.EventOptions) *trees.
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func UnloadEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("unload", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("unload")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10583,32 +4885,12 @@ func UnloadEvent(callback interface{}, options ...trees.EventOptions) *trees.Eve
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func UpdateReadyEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("UpdateReady", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("UpdateReady")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10620,32 +4902,12 @@ func UpdateReadyEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func UpgradeNeededEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("UpgradeNeeded", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("UpgradeNeeded")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10657,32 +4919,12 @@ func UpgradeNeededEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func UserProximityEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("UserProximity", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("UserProximity")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10694,32 +4936,12 @@ func UserProximityEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func UssdreceivedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("ussdreceived", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("ussdreceived")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10731,32 +4953,12 @@ func UssdreceivedEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func ValueChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("ValueChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("ValueChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10768,32 +4970,12 @@ func ValueChangeEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VersionChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("VersionChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("VersionChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10805,32 +4987,12 @@ func VersionChangeEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VisibilityChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("VisibilityChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("VisibilityChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10842,32 +5004,12 @@ func VisibilityChangeEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VoicechangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("voicechange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("voicechange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10879,32 +5021,12 @@ func VoicechangeEvent(callback interface{}, options ...trees.EventOptions) *tree
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VoiceschangedEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("voiceschanged", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("voiceschanged")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10916,32 +5038,12 @@ func VoiceschangedEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VolumeChangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("VolumeChange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("VolumeChange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10953,32 +5055,12 @@ func VolumeChangeEvent(callback interface{}, options ...trees.EventOptions) *tre
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VrdisplayactivateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("vrdisplayactivate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("vrdisplayactivate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -10990,32 +5072,12 @@ func VrdisplayactivateEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VrdisplayblurEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("vrdisplayblur", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("vrdisplayblur")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -11027,32 +5089,12 @@ func VrdisplayblurEvent(callback interface{}, options ...trees.EventOptions) *tr
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VrdisplayconnectEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("vrdisplayconnect", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("vrdisplayconnect")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -11064,32 +5106,12 @@ func VrdisplayconnectEvent(callback interface{}, options ...trees.EventOptions)
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VrdisplaydeactivateEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("vrdisplaydeactivate", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("vrdisplaydeactivate")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -11101,32 +5123,12 @@ func VrdisplaydeactivateEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VrdisplaydisconnectEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("vrdisplaydisconnect", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("vrdisplaydisconnect")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -11138,32 +5140,12 @@ func VrdisplaydisconnectEvent(callback interface{}, options ...trees.EventOption
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VrdisplayfocusEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("vrdisplayfocus", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("vrdisplayfocus")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -11175,32 +5157,12 @@ func VrdisplayfocusEvent(callback interface{}, options ...trees.EventOptions) *t
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func VrdisplaypresentchangeEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("vrdisplaypresentchange", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("vrdisplaypresentchange")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -11212,32 +5174,12 @@ func VrdisplaypresentchangeEvent(callback interface{}, options ...trees.EventOpt
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func WaitingEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("waiting", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("waiting")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -11249,32 +5191,12 @@ func WaitingEvent(callback interface{}, options ...trees.EventOptions) *trees.Ev
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func WheelEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type) {
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler("wheel", callback)
 
 	ops := append([]trees.EventOptions{trees.EventType("wheel")}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
-		if ev.ID() != evm.EventID {
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
@@ -185,12 +185,14 @@ package events
 
 import (
 	"github.com/gu-io/trees"
-	"github.com/gu-io/trees/notifications"
 )
 
 
 // EventHandler defines a function type for event callbacks.
-type EventHandler func(trees.EventObject, *trees.Markup)
+type EventHandler = trees.EventHandler
+
+// Middleware defines a function type which wraps a EventHandler.
+type Middleware = trees.Middleware
 
 // WrapHandler wraps the function returning a EventHandler to call the provided
 // function to be called when the event occurs without need for the arguments.
@@ -219,36 +221,16 @@ func WrapEventOnlyHandler(callback func(trees.EventObject)) EventHandler {
 // appropriate selector matching its type and uid value in this format  (ElementType[uid='UID_VALUE']) but if
 // the selector value is not empty then that becomes the default selector used match the event with.
 func %sEvent(callback interface{}, options ...trees.EventOptions) *trees.Event {
-	var handler EventHandler
-
-	switch cb := callback.(type){
-	case func():
-		handler = WrapHandler(cb)
-	case func(trees.EventObject):
-		handler = WrapEventOnlyHandler(cb)
-	case func(trees.EventObject, *trees.Markup):
-		handler = cb
-	default:
-		panic("Unacceptable type for event callback")
-	}
+	handler := MakeEventHandler(%q, callback)
 
 	ops := append([]trees.EventOptions{trees.EventType(%q)}, options...)
 
 	ev := trees.NewEvent(ops...)
-
-	eventHandler := trees.NewEventBroadcastHandler(func (evm trees.EventBroadcast){
-		if ev.ID() != evm.EventID{
-			return
-		}
-
-		handler(evm.Event, ev.Tree)
-	})
-
-	ev.Remove = notifications.SubscribeWithRemover(eventHandler)
+	ev.Handler = handler
 
 	return ev
 }
-`, name, e.Desc, e.Link[6:], name, e.Name)
+`, name, e.Desc, e.Link[6:], name, e.Name, e.Name)
 	}
 }
 
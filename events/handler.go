@@ -0,0 +1,174 @@
+package events
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gu-io/trees"
+)
+
+var (
+	eventObjectType = reflect.TypeOf((*trees.EventObject)(nil)).Elem()
+	markupType      = reflect.TypeOf((*trees.Markup)(nil))
+)
+
+// MakeHandler returns the EventHandler for the giving callback, which must be
+// one of the following function types, else it panics:
+//
+//	func()
+//	func(trees.EventObject)
+//	func(trees.EventObject, *trees.Markup)
+//
+// or a typed handler receiving a pointer to one of the event payloads, with
+// or without the *trees.Markup, e.g func(*trees.MouseEvent) or
+// func(*trees.KeyboardEvent, *trees.Markup). Typed handlers are only called
+// for events of their payload type or embedding it, e.g a
+// func(*trees.MouseEvent) is called for a *trees.DragEvent or a
+// *trees.WheelEvent with their embedded MouseEvent.
+func MakeHandler(callback interface{}) EventHandler {
+	switch cb := callback.(type) {
+	case EventHandler:
+		return cb
+	case func():
+		return WrapHandler(cb)
+	case func(trees.EventObject):
+		return WrapEventOnlyHandler(cb)
+	case func(trees.EventObject, *trees.Markup):
+		return cb
+	}
+
+	payload := handlerPayload(callback)
+	if payload == nil {
+		panic("Unacceptable type for event callback")
+	}
+
+	fn := reflect.ValueOf(callback)
+	withRoot := fn.Type().NumIn() == 2
+
+	return func(ev trees.EventObject, root *trees.Markup) {
+		if ev == nil {
+			return
+		}
+
+		typed, ok := embedded(reflect.ValueOf(ev), payload)
+		if !ok {
+			return
+		}
+
+		if withRoot {
+			fn.Call([]reflect.Value{typed, reflect.ValueOf(root)})
+			return
+		}
+
+		fn.Call([]reflect.Value{typed})
+	}
+}
+
+// MakeEventHandler returns the EventHandler for the callback as done by
+// MakeHandler, for events of the giving type. It panics if the callback is a
+// typed handler whose payload is never received by events of the type, e.g a
+// func(*trees.KeyboardEvent) for "click". Events of types without a
+// registered payload accept any typed handler.
+func MakeEventHandler(eventType string, callback interface{}) EventHandler {
+	handler := MakeHandler(callback)
+
+	payload := handlerPayload(callback)
+	if payload == nil {
+		return handler
+	}
+
+	sample, err := trees.DecodeEventAs(eventType, []byte("{}"))
+	if err != nil {
+		return handler
+	}
+
+	if _, ok := sample.(*trees.BaseEvent); ok {
+		return handler
+	}
+
+	if !embeds(reflect.TypeOf(sample), payload) {
+		panic(fmt.Sprintf("Unacceptable callback for %q events: %s never receives %s", eventType, reflect.TypeOf(sample), payload))
+	}
+
+	return handler
+}
+
+// handlerPayload returns the payload type received by the callback if it is
+// a typed handler, else nil.
+func handlerPayload(callback interface{}) reflect.Type {
+	if callback == nil {
+		return nil
+	}
+
+	fnType := reflect.TypeOf(callback)
+	if fnType.Kind() != reflect.Func || fnType.NumOut() != 0 || fnType.IsVariadic() {
+		return nil
+	}
+
+	if fnType.NumIn() != 1 && fnType.NumIn() != 2 {
+		return nil
+	}
+
+	if fnType.NumIn() == 2 && fnType.In(1) != markupType {
+		return nil
+	}
+
+	payload := fnType.In(0)
+	if payload.Kind() != reflect.Ptr || payload.Elem().Kind() != reflect.Struct || !payload.Implements(eventObjectType) {
+		return nil
+	}
+
+	return payload
+}
+
+// embedded returns the pointer of the type held by the value, which is either
+// the value itself or a pointer to one of the exported structs it embeds.
+func embedded(value reflect.Value, target reflect.Type) (reflect.Value, bool) {
+	if value.Type() == target {
+		return value, true
+	}
+
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	elem := value.Elem()
+	for index := 0; index < elem.NumField(); index++ {
+		field := elem.Type().Field(index)
+		if !field.Anonymous || field.PkgPath != "" || field.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		if found, ok := embedded(elem.Field(index).Addr(), target); ok {
+			return found, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// embeds returns true/false if the pointer type is the target or holds it
+// through its embedded structs, as found by embedded.
+func embeds(from reflect.Type, target reflect.Type) bool {
+	if from == target {
+		return true
+	}
+
+	if from.Kind() != reflect.Ptr || from.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	elem := from.Elem()
+	for index := 0; index < elem.NumField(); index++ {
+		field := elem.Field(index)
+		if !field.Anonymous || field.PkgPath != "" || field.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		if embeds(reflect.PtrTo(field.Type), target) {
+			return true
+		}
+	}
+
+	return false
+}
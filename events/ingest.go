@@ -0,0 +1,287 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/notifications"
+)
+
+// contains the default limits of a IngestHandler.
+const (
+	DefaultMaxBytes  = 1 << 20
+	DefaultMaxEvents = 100
+)
+
+// contains the codes of the errors returned by a IngestHandler.
+const (
+	ErrCodeMethod        = "method_not_allowed"
+	ErrCodeTooLarge      = "too_large"
+	ErrCodeTooManyEvents = "too_many_events"
+	ErrCodeMalformed     = "malformed"
+	ErrCodeUnknownEvent  = "unknown_event"
+	ErrCodeStaleTarget   = "stale_target"
+	ErrCodeTypeMismatch  = "type_mismatch"
+	ErrCodeInvalidEvent  = "invalid_event"
+)
+
+// IngestEvent defines a event payload posted by a client, as sent by the
+// client runtime.
+type IngestEvent struct {
+	EventID string          `json:"event_id"`
+	Event   string          `json:"event,omitempty"`
+	Type    string          `json:"type,omitempty"`
+	Target  string          `json:"target,omitempty"`
+	Body    json.RawMessage `json:"event_object"`
+}
+
+// IngestError defines the structured error returned for a rejected request or
+// event, with the index of the event within the batch, or -1 for errors of
+// the request.
+type IngestError struct {
+	Index   int    `json:"index"`
+	EventID string `json:"event_id,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error returns the message of the error.
+func (i IngestError) Error() string {
+	return i.Message
+}
+
+// IngestResponse defines the json response of a IngestHandler.
+type IngestResponse struct {
+	Delivered int           `json:"delivered"`
+	Errors    []IngestError `json:"errors,omitempty"`
+}
+
+// IngestHandler defines a http.Handler accepting event payloads posted by
+// clients, either a single IngestEvent or a batch of them in a json array.
+// Events are validated against the events registered on the root and its
+// descendants, rejecting unknown event ids, targets whose uid does not match
+// the event's markup or whose markup or one of its ancestors was removed, and
+// mismatched types. A batch is only delivered if all its events are valid,
+// each through the Deliver method of the handler's EventBroadcastHandler.
+//
+// Events are validated and delivered within Markup.Update of the root, so
+// their handlers change the tree while holding its lock and must not call
+// Update or View of the same tree themselves.
+//
+// Responses are IngestResponse json values, with the status 200 once
+// delivered, 400 for malformed requests, 405 for methods other than POST, 413
+// for requests over the size or event limits and 422 for invalid events.
+type IngestHandler struct {
+	MaxBytes  int64
+	MaxEvents int
+
+	root *trees.Markup
+	sink *trees.EventBroadcastHandler
+}
+
+// NewIngestHandler returns a new IngestHandler validating events against the
+// root and delivering them to the sink. A nil sink delivers events to the
+// dispatcher of the root, or to the global notifications dispatcher if it has
+// none.
+func NewIngestHandler(root *trees.Markup, sink *trees.EventBroadcastHandler) *IngestHandler {
+	if sink == nil {
+		sink = trees.NewEventBroadcastHandler(func(evm trees.EventBroadcast) {
+			if d := root.Dispatcher(); d != nil {
+				d.Dispatch(evm)
+				return
+			}
+
+			notifications.Dispatch(evm)
+		})
+	}
+
+	return &IngestHandler{
+		MaxBytes:  DefaultMaxBytes,
+		MaxEvents: DefaultMaxEvents,
+		root:      root,
+		sink:      sink,
+	}
+}
+
+// ServeHTTP validates and delivers the events of the request.
+func (h *IngestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		h.respond(w, http.StatusMethodNotAllowed, IngestResponse{Errors: []IngestError{requestError(ErrCodeMethod, "Only POST requests are accepted")}})
+		return
+	}
+
+	if h.MaxBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.MaxBytes)
+	}
+
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(r.Body); err != nil {
+		if _, ok := err.(*http.MaxBytesError); ok {
+			h.respond(w, http.StatusRequestEntityTooLarge, IngestResponse{Errors: []IngestError{requestError(ErrCodeTooLarge, fmt.Sprintf("Request body exceeds %d bytes", h.MaxBytes))}})
+			return
+		}
+
+		h.respond(w, http.StatusBadRequest, IngestResponse{Errors: []IngestError{requestError(ErrCodeMalformed, err.Error())}})
+		return
+	}
+
+	batch, err := DecodeBatch(body.Bytes())
+	if err != nil {
+		h.respond(w, http.StatusBadRequest, IngestResponse{Errors: []IngestError{requestError(ErrCodeMalformed, err.Error())}})
+		return
+	}
+
+	if h.MaxEvents > 0 && len(batch) > h.MaxEvents {
+		h.respond(w, http.StatusRequestEntityTooLarge, IngestResponse{Errors: []IngestError{requestError(ErrCodeTooManyEvents, fmt.Sprintf("Request has %d events, more than %d", len(batch), h.MaxEvents))}})
+		return
+	}
+
+	var broadcasts []trees.EventBroadcast
+	var errs []IngestError
+
+	// the targets can not be removed between their validation and delivery.
+	h.root.Update(func(root *trees.Markup) {
+		if broadcasts, errs = validate(root, batch); len(errs) != 0 {
+			return
+		}
+
+		for _, evm := range broadcasts {
+			h.sink.Deliver(evm.EventName, evm.EventID, evm.Event)
+		}
+	})
+
+	if len(errs) != 0 {
+		h.respond(w, http.StatusUnprocessableEntity, IngestResponse{Errors: errs})
+		return
+	}
+
+	h.respond(w, http.StatusOK, IngestResponse{Delivered: len(broadcasts)})
+}
+
+// Validate returns the EventBroadcast of each event of the batch, or the
+// errors of the invalid events, validated against the events registered on
+// the root and its descendants as done by IngestHandler, while holding the
+// read lock of the root.
+func Validate(root *trees.Markup, batch []IngestEvent) ([]trees.EventBroadcast, []IngestError) {
+	var broadcasts []trees.EventBroadcast
+	var errs []IngestError
+
+	root.View(func(root *trees.Markup) {
+		broadcasts, errs = validate(root, batch)
+	})
+
+	return broadcasts, errs
+}
+
+// validate returns the EventBroadcast of each event of the batch, or the
+// errors of the invalid events, without locking the root.
+func validate(root *trees.Markup, batch []IngestEvent) ([]trees.EventBroadcast, []IngestError) {
+	registered := make(map[string]*trees.Event)
+	owners := make(map[string]*trees.Markup)
+
+	root.EachEvent(func(ev *trees.Event, owner *trees.Markup) {
+		if ev.Handler == nil {
+			return
+		}
+
+		registered[ev.ID()] = ev
+		owners[ev.ID()] = owner
+	})
+
+	var broadcasts []trees.EventBroadcast
+	var errs []IngestError
+
+	for index, item := range batch {
+		fail := func(code string, message string, args ...interface{}) {
+			errs = append(errs, IngestError{Index: index, EventID: item.EventID, Code: code, Message: fmt.Sprintf(message, args...)})
+		}
+
+		ev, ok := registered[item.EventID]
+		if !ok {
+			fail(ErrCodeUnknownEvent, "Event %q is not registered", item.EventID)
+			continue
+		}
+
+		owner := owners[item.EventID]
+		if !attached(root, owner) || (item.Target != "" && item.Target != owner.UID()) {
+			fail(ErrCodeStaleTarget, "Target %q is not the markup of event %q", item.Target, item.EventID)
+			continue
+		}
+
+		if item.Type != "" && !strings.EqualFold(item.Type, ev.Type) {
+			fail(ErrCodeTypeMismatch, "Type %q does not match event type %q", item.Type, ev.Type)
+			continue
+		}
+
+		eventType := item.Type
+		if eventType == "" {
+			eventType = ev.Type
+		}
+
+		obj, err := trees.DecodeEventAs(eventType, item.Body)
+		if err != nil {
+			fail(ErrCodeInvalidEvent, "Event body is invalid: %s", err)
+			continue
+		}
+
+		name := item.Event
+		if name == "" {
+			name = ev.EventName()
+		}
+
+		broadcasts = append(broadcasts, trees.EventBroadcast{EventName: name, EventID: item.EventID, Event: obj})
+	}
+
+	return broadcasts, errs
+}
+
+// attached returns true/false if the markup and its ancestors up to the root
+// are not removed.
+func attached(root *trees.Markup, m *trees.Markup) bool {
+	for node := m; node != nil; node = node.Parent() {
+		if node.Removed() {
+			return false
+		}
+
+		if node == root {
+			return true
+		}
+	}
+
+	return false
+}
+
+// respond writes the json response with the status.
+func (h *IngestHandler) respond(w http.ResponseWriter, status int, res IngestResponse) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res)
+}
+
+// requestError returns a IngestError for the request.
+func requestError(code string, message string) IngestError {
+	return IngestError{Index: -1, Code: code, Message: message}
+}
+
+// DecodeBatch decodes a single IngestEvent or a json array of them.
+func DecodeBatch(data []byte) ([]IngestEvent, error) {
+	data = bytes.TrimSpace(data)
+
+	if len(data) != 0 && data[0] == '[' {
+		var batch []IngestEvent
+		err := json.Unmarshal(data, &batch)
+		return batch, err
+	}
+
+	var item IngestEvent
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+
+	return []IngestEvent{item}, nil
+}
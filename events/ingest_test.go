@@ -0,0 +1,99 @@
+package events_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+	"github.com/influx6/faux/tests"
+)
+
+func TestIngestHandler(t *testing.T) {
+	root := trees.NewMarkup("div", false)
+	trees.NewDispatcher().Apply(root)
+	defer root.Empty()
+
+	button := trees.NewMarkup("button", false)
+	button.Apply(root)
+
+	var clicks []*trees.MouseEvent
+
+	click := events.ClickEvent(func(ev *trees.MouseEvent) {
+		clicks = append(clicks, ev)
+	})
+	click.Apply(button)
+
+	handler := events.NewIngestHandler(root, nil)
+
+	post := func(body string) (*httptest.ResponseRecorder, events.IngestResponse) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("POST", "/events", strings.NewReader(body)))
+
+		var res events.IngestResponse
+		json.Unmarshal(rec.Body.Bytes(), &res)
+		return rec, res
+	}
+
+	item := func(id, target, body string) string {
+		return fmt.Sprintf(`{"event":"ClickEvent","event_id":%q,"type":"click","target":%q,"event_object":%s}`, id, target, body)
+	}
+
+	rec, res := post("[" + item(click.ID(), button.UID(), `{"clientX":4}`) + "," + item(click.ID(), "", `{"clientX":8}`) + "]")
+	if rec.Code != http.StatusOK || res.Delivered != 2 || len(clicks) != 2 || clicks[1].ClientX != 8 {
+		tests.Failed("Should have delivered batched events: %d %s", rec.Code, rec.Body.String())
+	}
+	tests.Passed("Should have delivered batched events")
+
+	rec, res = post("[" + item(click.ID(), button.UID(), `{}`) + "," + item("button[uid='gone']#click", "gone", `{}`) + "," + item(click.ID(), "other", `{}`) + "]")
+	if rec.Code != http.StatusUnprocessableEntity || len(res.Errors) != 2 || len(clicks) != 2 {
+		tests.Failed("Should have rejected batch with invalid events: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if res.Errors[0].Code != events.ErrCodeUnknownEvent || res.Errors[0].Index != 1 || res.Errors[1].Code != events.ErrCodeStaleTarget {
+		tests.Failed("Should have returned structured errors of invalid events: %s", rec.Body.String())
+	}
+	tests.Passed("Should have rejected batch with unknown and stale events")
+
+	handler.MaxBytes = 64
+	rec, res = post("[" + item(click.ID(), button.UID(), `{}`) + "]")
+	if rec.Code != http.StatusRequestEntityTooLarge || len(res.Errors) != 1 || res.Errors[0].Code != events.ErrCodeTooLarge {
+		tests.Failed("Should have rejected request over size limit: %d %s", rec.Code, rec.Body.String())
+	}
+	tests.Passed("Should have rejected request over size limit")
+
+	handler.MaxBytes = events.DefaultMaxBytes
+	rec, res = post("{")
+	if rec.Code != http.StatusBadRequest || res.Errors[0].Code != events.ErrCodeMalformed {
+		tests.Failed("Should have rejected malformed request: %d %s", rec.Code, rec.Body.String())
+	}
+	tests.Passed("Should have rejected malformed request")
+
+	button.Remove()
+	rec, res = post(item(click.ID(), button.UID(), `{}`))
+	if rec.Code != http.StatusUnprocessableEntity || res.Errors[0].Code != events.ErrCodeStaleTarget {
+		tests.Failed("Should have rejected event of removed markup: %d %s", rec.Code, rec.Body.String())
+	}
+	tests.Passed("Should have rejected event of removed markup")
+
+	section := trees.NewMarkup("section", false)
+	link := trees.NewMarkup("a", false)
+	link.Apply(section)
+	section.Apply(root)
+
+	nested := events.ClickEvent(func(ev *trees.MouseEvent) {
+		clicks = append(clicks, ev)
+	})
+	nested.Apply(link)
+
+	section.Remove()
+	rec, res = post(item(nested.ID(), link.UID(), `{}`))
+	if rec.Code != http.StatusUnprocessableEntity || res.Errors[0].Code != events.ErrCodeStaleTarget || len(clicks) != 2 {
+		tests.Failed("Should have rejected event of markup within removed markup: %d %s", rec.Code, rec.Body.String())
+	}
+	tests.Passed("Should have rejected event of markup within removed markup")
+}
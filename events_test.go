@@ -0,0 +1,179 @@
+package trees_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+	"github.com/gu-io/trees/notifications"
+)
+
+func TestEventLifetime(t *testing.T) {
+	var calls int
+
+	fire := func(ev *trees.Event) {
+		notifications.Dispatch(trees.EventBroadcast{EventName: "ClickEvent", EventID: ev.ID(), Event: &trees.BaseEvent{Type: "click"}})
+	}
+
+	list := trees.NewMarkup("ul", false)
+	item := trees.NewMarkup("li", false)
+	item.Apply(list)
+
+	click := events.ClickEvent(func() { calls++ })
+	if click.Remove != nil {
+		t.Fatalf("\t%s\t  Should have not subscribed unapplied event", failed)
+	}
+	t.Logf("\t%s\t  Should have not subscribed unapplied event", success)
+
+	click.Apply(item)
+
+	fire(click)
+	if calls != 1 {
+		t.Fatalf("\t%s\t  Should have called handler of applied event: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have called handler of applied event", success)
+
+	clone := item.Clone()
+	cloned := clone.Events()[0]
+
+	if cloned.Remove != nil {
+		t.Fatalf("\t%s\t  Should have left event of clone sharing the uid unsubscribed", failed)
+	}
+	t.Logf("\t%s\t  Should have left event of clone sharing the uid unsubscribed", success)
+
+	fire(click)
+	if calls != 2 {
+		t.Fatalf("\t%s\t  Should have called handler once for markup and clone: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have called handler once for markup and clone", success)
+
+	item.Remove()
+	list.Clean()
+
+	fire(click)
+	if calls != 2 {
+		t.Fatalf("\t%s\t  Should have unsubscribed events of cleaned markup: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have unsubscribed events of cleaned markup", success)
+
+	clone.SwapUID(item.UID())
+	cloned = clone.Events()[0]
+
+	fire(&cloned)
+	if calls != 3 {
+		t.Fatalf("\t%s\t  Should have subscribed events of clone given its uid: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have subscribed events of clone given its uid", success)
+
+	box := trees.NewMarkup("div", false)
+	clone.Apply(box)
+	box.Empty()
+
+	fire(&cloned)
+	if calls != 3 {
+		t.Fatalf("\t%s\t  Should have unsubscribed events of emptied markup: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have unsubscribed events of emptied markup", success)
+
+	clone.Apply(box)
+
+	fire(&cloned)
+	if calls != 4 {
+		t.Fatalf("\t%s\t  Should have resubscribed events of added markup: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have resubscribed events of added markup", success)
+
+	box.Release()
+
+	fire(&cloned)
+	if calls != 4 {
+		t.Fatalf("\t%s\t  Should have unsubscribed events of released tree: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have unsubscribed events of released tree", success)
+}
+
+func TestEventLifetimeReconcile(t *testing.T) {
+	var calls int
+
+	fire := func(ev trees.Event) {
+		notifications.Dispatch(trees.EventBroadcast{EventName: "ClickEvent", EventID: ev.ID(), Event: &trees.BaseEvent{Type: "click"}})
+	}
+
+	render := func(tag string, children bool) *trees.Markup {
+		root := trees.NewMarkup(tag, false)
+		events.ClickEvent(func() { calls++ }).Apply(root)
+
+		if children {
+			item := trees.NewMarkup("li", false)
+			events.ClickEvent(func() { calls++ }).Apply(item)
+			item.Apply(root)
+		}
+
+		return root
+	}
+
+	old := render("ul", true)
+	item := old.Children()[0].Events()[0]
+
+	current := render("ul", false)
+	defer current.Release()
+
+	current.Reconcile(old)
+
+	fire(item)
+	if calls != 0 {
+		t.Fatalf("\t%s\t  Should have unsubscribed children dropped by reconcile: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have unsubscribed children dropped by reconcile", success)
+
+	old = render("ul", true)
+	root, item := old.Events()[0], old.Children()[0].Events()[0]
+
+	replaced := render("ol", false)
+	defer replaced.Release()
+
+	replaced.Reconcile(old)
+
+	fire(root)
+	fire(item)
+	if calls != 0 {
+		t.Fatalf("\t%s\t  Should have unsubscribed markup replaced by other type: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have unsubscribed markup replaced by other type", success)
+}
+
+func TestEventLifetimeRemovedByHandler(t *testing.T) {
+	var calls int
+
+	root := trees.NewMarkup("div", false)
+	btn := trees.NewMarkup("button", false)
+	btn.Apply(root)
+
+	click := events.ClickEvent(func() {
+		calls++
+		btn.Remove()
+		root.Clean()
+	})
+	click.Apply(btn)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		notifications.Dispatch(trees.EventBroadcast{EventName: "ClickEvent", EventID: click.ID(), Event: &trees.BaseEvent{Type: "click"}})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("\t%s\t  Should have removed markup from within its handler", failed)
+	}
+	t.Logf("\t%s\t  Should have removed markup from within its handler", success)
+
+	notifications.Dispatch(trees.EventBroadcast{EventName: "ClickEvent", EventID: click.ID(), Event: &trees.BaseEvent{Type: "click"}})
+	if calls != 1 {
+		t.Fatalf("\t%s\t  Should have unsubscribed handler removing its markup: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have unsubscribed handler removing its markup", success)
+}
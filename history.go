@@ -0,0 +1,485 @@
+package trees
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// History defines a undo/redo log of the mutations made on a markup tree.
+// Mutations are grouped into transactions, where each committed transaction
+// is a single step of the log. Mutations made outside of a transaction are
+// recorded as their own step, where all mutations of a single call (e.g
+// Empty or Clean) are a single step.
+type History struct {
+	root     *Markup
+	observer *MutationObserver
+	current  *Transaction
+	undo     []*Transaction
+	redo     []*Transaction
+	applying bool
+}
+
+// NewHistory returns a new History recording all mutations on the root and
+// its descendants, replacing any history already recording the root.
+func NewHistory(root *Markup) *History {
+	if root.history != nil {
+		root.history.Detach()
+	}
+
+	h := &History{root: root}
+	h.observer = root.Observe(h.record)
+	root.history = h
+
+	return h
+}
+
+// History returns the History recording the markup's mutations, if any.
+func (e *Markup) History() *History {
+	return e.history
+}
+
+// Begin starts a new transaction on the history of the root, creating a new
+// History for the root if it has none.
+func Begin(root *Markup) *Transaction {
+	h := root.history
+	if h == nil {
+		h = NewHistory(root)
+	}
+
+	return h.Begin()
+}
+
+// Root returns the markup root of the history.
+func (h *History) Root() *Markup {
+	return h.root
+}
+
+// Detach stops the history from recording mutations of its root.
+func (h *History) Detach() {
+	h.observer.Disconnect()
+
+	if h.root.history == h {
+		h.root.history = nil
+	}
+}
+
+// Begin starts a new transaction. If a transaction is already open then the
+// new transaction is nested within it and its mutations become part of the
+// open transaction once committed.
+func (h *History) Begin() *Transaction {
+	tx := &Transaction{history: h, parent: h.current}
+	h.current = tx
+	return tx
+}
+
+// CanUndo returns true/false if there is a step to be undone.
+func (h *History) CanUndo() bool {
+	return len(h.undo) != 0
+}
+
+// CanRedo returns true/false if there is a step to be redone.
+func (h *History) CanRedo() bool {
+	return len(h.redo) != 0
+}
+
+// Undo reverts the last committed step of the history, returning false if
+// there is none or a transaction is still open.
+func (h *History) Undo() bool {
+	if h.current != nil || len(h.undo) == 0 {
+		return false
+	}
+
+	tx := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+
+	h.revert(tx.records)
+	h.redo = append(h.redo, tx)
+
+	return true
+}
+
+// Redo replays the last undone step of the history, returning false if there
+// is none or a transaction is still open.
+func (h *History) Redo() bool {
+	if h.current != nil || len(h.redo) == 0 {
+		return false
+	}
+
+	tx := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+
+	h.replay(tx.records)
+	h.undo = append(h.undo, tx)
+
+	return true
+}
+
+// Clear drops all steps of the history.
+func (h *History) Clear() {
+	h.undo = nil
+	h.redo = nil
+}
+
+// HistoryJSON defines a struct which contains the undo and redo steps of a
+// history.
+type HistoryJSON struct {
+	Undo []TransactionJSON `json:"Undo"`
+	Redo []TransactionJSON `json:"Redo"`
+}
+
+// TransactionJSON defines a struct which contains the label and mutations of
+// a transaction.
+type TransactionJSON struct {
+	Label     string         `json:"Label"`
+	Mutations []MutationJSON `json:"Mutations"`
+}
+
+// MutationJSON defines a struct which contains a mutation record, where
+// markups are referred to by uid and added or removed children by their html.
+type MutationJSON struct {
+	Type     string `json:"Type"`
+	Target   string `json:"Target"`
+	Child    string `json:"Child,omitempty"`
+	Markup   string `json:"Markup,omitempty"`
+	Index    int    `json:"Index"`
+	Name     string `json:"Name,omitempty"`
+	Value    string `json:"Value,omitempty"`
+	OldValue string `json:"OldValue,omitempty"`
+	Added    bool   `json:"Added"`
+}
+
+// HistoryJSON returns the HistoryJSON for the history.
+func (h *History) HistoryJSON() HistoryJSON {
+	var hjson HistoryJSON
+
+	for _, tx := range h.undo {
+		hjson.Undo = append(hjson.Undo, tx.TransactionJSON())
+	}
+
+	for _, tx := range h.redo {
+		hjson.Redo = append(hjson.Redo, tx.TransactionJSON())
+	}
+
+	return hjson
+}
+
+// MarshalJSON returns the json representation of the history.
+func (h *History) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.HistoryJSON())
+}
+
+// record adds the mutation into the open transaction or as a new step.
+func (h *History) record(rec MutationRecord) {
+	if h.applying {
+		return
+	}
+
+	if h.current != nil {
+		h.current.records = append(h.current.records, rec)
+		return
+	}
+
+	h.undo = append(h.undo, &Transaction{history: h, records: []MutationRecord{rec}, done: true})
+	h.redo = nil
+}
+
+// group begins a transaction on every history recording the markup, so the
+// mutations made by a single call on the markup are undone as a single step
+// even outside of a transaction. The returned function commits them.
+func (e *Markup) group() func() {
+	var txs []*Transaction
+
+	for node := e; node != nil; node = node.parent {
+		if h := node.history; h != nil && !h.applying {
+			txs = append(txs, h.Begin())
+		}
+	}
+
+	return func() {
+		for _, tx := range txs {
+			tx.Commit()
+		}
+	}
+}
+
+// revert applies the inverse of the records in reverse order.
+func (h *History) revert(records []MutationRecord) {
+	h.applying = true
+	defer func() { h.applying = false }()
+
+	for index := len(records) - 1; index >= 0; index-- {
+		rec := records[index]
+
+		switch rec.Type {
+		case ChildAdded:
+			rec.Target.removeChild(rec.Index, rec.Child)
+		case ChildRemoved:
+			rec.Target.insertChild(rec.Index, rec.Child)
+		case AttributeSet:
+			if rec.Added {
+				rec.Target.dropProperty(false, rec.Name)
+			} else {
+				rec.Target.setProperty(false, rec.Name, rec.OldValue)
+			}
+		case StyleSet:
+			if rec.Added {
+				rec.Target.dropProperty(true, rec.Name)
+			} else {
+				rec.Target.setProperty(true, rec.Name, rec.OldValue)
+			}
+		case StyleRemoved:
+			rec.Target.AddStyle(NewCSSStyle(rec.Name, rec.OldValue))
+		case TextChanged:
+			rec.Target.SetText(rec.OldValue)
+		case NodeRemoved:
+			rec.Target.UnRemove()
+		case NodeUnRemoved:
+			rec.Target.Remove()
+		}
+	}
+}
+
+// replay applies the records in order.
+func (h *History) replay(records []MutationRecord) {
+	h.applying = true
+	defer func() { h.applying = false }()
+
+	for _, rec := range records {
+		switch rec.Type {
+		case ChildAdded:
+			rec.Target.insertChild(rec.Index, rec.Child)
+		case ChildRemoved:
+			rec.Target.removeChild(rec.Index, rec.Child)
+		case AttributeSet:
+			if rec.Added {
+				rec.Target.AddAttribute(NewAttr(rec.Name, rec.Value))
+			} else {
+				rec.Target.setProperty(false, rec.Name, rec.Value)
+			}
+		case StyleSet:
+			if rec.Added {
+				rec.Target.AddStyle(NewCSSStyle(rec.Name, rec.Value))
+			} else {
+				rec.Target.setProperty(true, rec.Name, rec.Value)
+			}
+		case StyleRemoved:
+			rec.Target.dropProperty(true, rec.Name)
+		case TextChanged:
+			rec.Target.SetText(rec.Value)
+		case NodeRemoved:
+			rec.Target.Remove()
+		case NodeUnRemoved:
+			rec.Target.UnRemove()
+		}
+	}
+}
+
+//==============================================================================
+
+// Transaction defines a group of mutations recorded on a History which are
+// undone and redone together.
+type Transaction struct {
+	Label string
+
+	history *History
+	parent  *Transaction
+	records []MutationRecord
+	done    bool
+}
+
+// Records returns the mutations recorded by the transaction.
+func (tx *Transaction) Records() []MutationRecord {
+	return tx.records
+}
+
+// Commit ends the transaction, adding its mutations as a new step of the
+// history or into the transaction it is nested in.
+func (tx *Transaction) Commit() error {
+	if err := tx.end(); err != nil {
+		return err
+	}
+
+	if tx.parent != nil {
+		tx.parent.records = append(tx.parent.records, tx.records...)
+		return nil
+	}
+
+	if len(tx.records) != 0 {
+		tx.history.undo = append(tx.history.undo, tx)
+		tx.history.redo = nil
+	}
+
+	return nil
+}
+
+// Rollback ends the transaction, reverting all its mutations.
+func (tx *Transaction) Rollback() error {
+	if err := tx.end(); err != nil {
+		return err
+	}
+
+	tx.history.revert(tx.records)
+	tx.records = nil
+
+	return nil
+}
+
+// TransactionJSON returns the TransactionJSON for the transaction.
+func (tx *Transaction) TransactionJSON() TransactionJSON {
+	tjson := TransactionJSON{Label: tx.Label}
+
+	for _, rec := range tx.records {
+		mjson := MutationJSON{
+			Type:     rec.Type.String(),
+			Target:   rec.Target.UID(),
+			Index:    rec.Index,
+			Name:     rec.Name,
+			Value:    rec.Value,
+			OldValue: rec.OldValue,
+			Added:    rec.Added,
+		}
+
+		if rec.Child != nil {
+			mjson.Child = rec.Child.UID()
+			mjson.Markup = rec.Child.HTML()
+		}
+
+		tjson.Mutations = append(tjson.Mutations, mjson)
+	}
+
+	return tjson
+}
+
+// end marks the transaction as done and closes it on its history.
+func (tx *Transaction) end() error {
+	if tx.done {
+		return ErrTransactionDone
+	}
+
+	if tx.history.current != tx {
+		return ErrTransactionOpen
+	}
+
+	tx.done = true
+	tx.history.current = tx.parent
+
+	return nil
+}
+
+//==============================================================================
+
+// insertChild adds the child at the index of the markup's children.
+func (e *Markup) insertChild(index int, child *Markup) {
+	if index < 0 || index > len(e.children) {
+		index = len(e.children)
+	}
+
+	child.parent = e
+	e.children = append(e.children, nil)
+	copy(e.children[index+1:], e.children[index:])
+	e.children[index] = child
+	child.subscribeEvents()
+
+	e.notify(MutationRecord{Type: ChildAdded, Target: e, Child: child, Index: index})
+}
+
+// removeChild removes the child from the markup's children, using the index
+// when it still points to the child.
+func (e *Markup) removeChild(index int, child *Markup) {
+	if index < 0 || index >= len(e.children) || e.children[index] != child {
+		index = -1
+
+		for ind, ch := range e.children {
+			if ch == child {
+				index = ind
+				break
+			}
+		}
+
+		if index == -1 {
+			return
+		}
+	}
+
+	e.children = append(e.children[:index], e.children[index+1:]...)
+	child.parent = nil
+	child.unsubscribeEvents(true)
+
+	e.notify(MutationRecord{Type: ChildRemoved, Target: e, Child: child, Index: index})
+}
+
+// setProperty sets the value of the last attribute or style with the name,
+// adding a new one if not found.
+func (e *Markup) setProperty(style bool, name string, value string) {
+	if e.frozen {
+		return
+	}
+
+	props := e.attrs
+	if style {
+		props = e.styles
+	}
+
+	for index := len(props) - 1; index >= 0; index-- {
+		pname, old := props[index].Render()
+		if pname != name {
+			continue
+		}
+
+		switch prop := props[index].(type) {
+		case *Attribute:
+			prop.Value = value
+		case *CSSStyle:
+			prop.Value = value
+		case *ClassList:
+			prop.list = strings.Fields(value)
+		default:
+			continue
+		}
+
+		if style {
+			e.notify(MutationRecord{Type: StyleSet, Target: e, Name: name, Value: value, OldValue: old})
+		} else {
+			e.notify(MutationRecord{Type: AttributeSet, Target: e, Name: name, Value: value, OldValue: old})
+		}
+
+		return
+	}
+
+	if style {
+		e.AddStyle(NewCSSStyle(name, value))
+	} else {
+		e.AddAttribute(NewAttr(name, value))
+	}
+}
+
+// dropProperty removes the last attribute or style with the name.
+func (e *Markup) dropProperty(style bool, name string) {
+	if e.frozen {
+		return
+	}
+
+	props := e.attrs
+	if style {
+		props = e.styles
+	}
+
+	for index := len(props) - 1; index >= 0; index-- {
+		pname, old := props[index].Render()
+		if pname != name {
+			continue
+		}
+
+		props = append(props[:index], props[index+1:]...)
+
+		if style {
+			e.styles = props
+			e.notify(MutationRecord{Type: StyleRemoved, Target: e, Name: name, OldValue: old})
+		} else {
+			e.attrs = props
+			e.notify(MutationRecord{Type: AttributeSet, Target: e, Name: name, OldValue: old})
+		}
+
+		return
+	}
+}
@@ -0,0 +1,100 @@
+package trees_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+func TestHistory(t *testing.T) {
+	tree := trees.ParseAsRoot("div#page", `<section id="header"><h1>Title</h1></section>`)
+	original := tree.HTML()
+
+	tx := trees.Begin(tree)
+	tx.Label = "edit header"
+
+	header := trees.Query.Query(tree, "#header")
+	trees.ReplaceAttribute(header, "id", "top")
+	trees.NewCSSStyle("color", "red").Apply(header)
+	header.AddChild(trees.NewText("Subtitle"))
+
+	nested := tree.History().Begin()
+	tree.Children()[0].Children()[0].Remove()
+	if err := nested.Commit(); err != nil {
+		t.Fatalf("\t%s\t  Should have committed nested transaction: %+q", failed, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("\t%s\t  Should have committed transaction: %+q", failed, err)
+	}
+
+	edited := tree.HTML()
+	if edited == original {
+		t.Fatalf("\t%s\t  Should have changed markup", failed)
+	}
+	t.Logf("\t%s\t  Should have committed transaction", success)
+
+	history := tree.History()
+	if !history.Undo() || tree.HTML() != original || history.CanUndo() {
+		t.Fatalf("\t%s\t  Should have undone transaction: %s", failed, tree.HTML())
+	}
+	t.Logf("\t%s\t  Should have undone transaction", success)
+
+	if !history.Redo() || tree.HTML() != edited || history.CanRedo() {
+		t.Fatalf("\t%s\t  Should have redone transaction: %s", failed, tree.HTML())
+	}
+	t.Logf("\t%s\t  Should have redone transaction", success)
+
+	header.SetText("untracked")
+	if !history.Undo() || tree.HTML() != edited {
+		t.Fatalf("\t%s\t  Should have undone mutations outside transactions: %s", failed, tree.HTML())
+	}
+	t.Logf("\t%s\t  Should have undone mutations outside transactions", success)
+
+	rollback := trees.Begin(tree)
+	trees.NewAttr("lang", "en").Apply(tree)
+	header.Empty()
+	if err := rollback.Rollback(); err != nil || tree.HTML() != edited {
+		t.Fatalf("\t%s\t  Should have rolled back transaction: %s", failed, tree.HTML())
+	}
+
+	if err := rollback.Commit(); err != trees.ErrTransactionDone {
+		t.Fatalf("\t%s\t  Should have failed to commit rolled back transaction", failed)
+	}
+	t.Logf("\t%s\t  Should have rolled back transaction", success)
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.Fatalf("\t%s\t  Should have serialized history: %+q", failed, err)
+	}
+
+	var hjson trees.HistoryJSON
+	if err := json.Unmarshal(data, &hjson); err != nil || len(hjson.Undo) != 1 || hjson.Undo[0].Label != "edit header" || len(hjson.Undo[0].Mutations) != 4 {
+		t.Fatalf("\t%s\t  Should have serialized history: %s", failed, data)
+	}
+	t.Logf("\t%s\t  Should have serialized history", success)
+}
+
+func TestHistoryGroupsCalls(t *testing.T) {
+	list := trees.ParseAsRoot("ul", `<li>1</li><li>2</li><li>3</li><li>4</li>`)
+	original := list.HTML()
+
+	history := trees.NewHistory(list)
+	list.Empty()
+
+	if !history.Undo() || list.HTML() != original || history.CanUndo() {
+		t.Fatalf("\t%s\t  Should have undone Empty as a single step: %s", failed, list.HTML())
+	}
+	t.Logf("\t%s\t  Should have undone Empty as a single step", success)
+
+	list.Children()[1].Remove()
+	list.Children()[3].Remove()
+	history.Clear()
+
+	list.Clean()
+	if len(list.Children()) != 2 || !history.Undo() || len(list.Children()) != 4 || history.CanUndo() {
+		t.Fatalf("\t%s\t  Should have undone Clean as a single step: %s", failed, list.HTML())
+	}
+	t.Logf("\t%s\t  Should have undone Clean as a single step", success)
+}
@@ -0,0 +1,358 @@
+package trees
+
+import (
+	"sort"
+	"strings"
+)
+
+// markupSet defines a set of markup used by the Index for its lookups.
+type markupSet map[*Markup]struct{}
+
+// Index defines a lookup table attached to a root markup which provides near
+// constant time lookups of markup by their id, class, tag and attributes names.
+// The index is kept up to date with the changes made to the tree by observing
+// the mutations of the root, hence attributes must be changed through the
+// markup methods and the helpers of this package (e.g ReplaceORAddAttribute)
+// and not by changing the fields of a attribute directly.
+// Once attached, Query, GetAttr, ElementsWithTag and ElementsWithAttr
+// will use the index automatically for markup within the indexed tree.
+type Index struct {
+	root     *Markup
+	observer *MutationObserver
+	ids      map[string]markupSet
+	tags     map[string]markupSet
+	classes  map[string]markupSet
+	attrs    map[string]markupSet
+	nodes    map[*Markup]*indexEntry
+}
+
+// indexEntry holds the values a markup was indexed with.
+type indexEntry struct {
+	id      string
+	tag     string
+	classes []string
+	props   map[string]Property
+}
+
+// NewIndex returns a new Index for the provided root, indexing the root and all
+// its descendants.
+func NewIndex(root *Markup) *Index {
+	idx := &Index{
+		root:    root,
+		ids:     make(map[string]markupSet),
+		tags:    make(map[string]markupSet),
+		classes: make(map[string]markupSet),
+		attrs:   make(map[string]markupSet),
+		nodes:   make(map[*Markup]*indexEntry),
+	}
+
+	idx.add(root)
+	idx.observer = root.Observe(idx.update)
+
+	return idx
+}
+
+// Index returns the Index which currently covers the markup, if any.
+func (e *Markup) Index() *Index {
+	return e.indexed
+}
+
+// Root returns the markup root of the index.
+func (idx *Index) Root() *Markup {
+	return idx.root
+}
+
+// Detach stops the index from observing its root and detaches it from all
+// markup, which will be looked up linearly afterwards.
+func (idx *Index) Detach() {
+	if idx.observer == nil {
+		return
+	}
+
+	idx.observer.Disconnect()
+	idx.observer = nil
+
+	for node := range idx.nodes {
+		if node.indexed == idx {
+			node.indexed = nil
+		}
+	}
+
+	idx.ids = make(map[string]markupSet)
+	idx.tags = make(map[string]markupSet)
+	idx.classes = make(map[string]markupSet)
+	idx.attrs = make(map[string]markupSet)
+	idx.nodes = make(map[*Markup]*indexEntry)
+}
+
+// WithID returns all indexed markup with the provided id attribute in document
+// order.
+func (idx *Index) WithID(id string) []*Markup {
+	return idx.ordered(idx.ids[id])
+}
+
+// WithTag returns all indexed markup with the provided tag name in document
+// order.
+func (idx *Index) WithTag(tag string) []*Markup {
+	return idx.ordered(idx.tags[strings.ToLower(strings.TrimSpace(tag))])
+}
+
+// WithClass returns all indexed markup which have the provided class in their
+// class list in document order.
+func (idx *Index) WithClass(class string) []*Markup {
+	return idx.ordered(idx.classes[class])
+}
+
+// WithAttr returns all indexed markup which have a attribute with the provided
+// name in document order.
+func (idx *Index) WithAttr(name string) []*Markup {
+	return idx.ordered(idx.attrs[name])
+}
+
+// Attr returns the first attribute of the markup with the provided name.
+func (idx *Index) Attr(m *Markup, name string) (Property, error) {
+	if entry, ok := idx.nodes[m]; ok {
+		if attr, ok := entry.props[name]; ok {
+			return attr, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+//==============================================================================
+
+// update keeps the index up to date with the changes of the tree.
+func (idx *Index) update(rec MutationRecord) {
+	switch rec.Type {
+	case ChildAdded:
+		idx.add(rec.Child)
+	case ChildRemoved:
+		idx.drop(rec.Child)
+	case AttributeSet, NodeRemoved, NodeUnRemoved:
+		idx.unindex(rec.Target)
+		idx.index(rec.Target)
+	}
+}
+
+// add indexes the giving markup and all its descendants.
+func (idx *Index) add(m *Markup) {
+	idx.index(m)
+
+	for _, child := range m.children {
+		idx.add(child)
+	}
+}
+
+// drop removes the giving markup and all its descendants from the index.
+func (idx *Index) drop(m *Markup) {
+	idx.unindex(m)
+
+	if m.indexed == idx {
+		m.indexed = nil
+	}
+
+	for _, child := range m.children {
+		idx.drop(child)
+	}
+}
+
+// index adds the markup's tag and attributes into the lookup tables.
+func (idx *Index) index(m *Markup) {
+	m.indexed = idx
+
+	entry := &indexEntry{
+		tag:   m.tagname,
+		props: make(map[string]Property, len(m.attrs)),
+	}
+
+	for _, attr := range m.attrs {
+		name, value := attr.Render()
+		if _, ok := entry.props[name]; ok {
+			continue
+		}
+
+		entry.props[name] = attr
+		idx.insert(idx.attrs, name, m)
+
+		switch name {
+		case "id":
+			entry.id = value
+			idx.insert(idx.ids, value, m)
+		case "class":
+			entry.classes = strings.Fields(value)
+			for _, class := range entry.classes {
+				idx.insert(idx.classes, class, m)
+			}
+		}
+	}
+
+	idx.nodes[m] = entry
+	idx.insert(idx.tags, m.tagname, m)
+}
+
+// unindex removes the markup from the lookup tables using the values it
+// was indexed with.
+func (idx *Index) unindex(m *Markup) {
+	entry, ok := idx.nodes[m]
+	if !ok {
+		return
+	}
+
+	for name := range entry.props {
+		idx.remove(idx.attrs, name, m)
+	}
+
+	if _, ok := entry.props["id"]; ok {
+		idx.remove(idx.ids, entry.id, m)
+	}
+
+	for _, class := range entry.classes {
+		idx.remove(idx.classes, class, m)
+	}
+
+	idx.remove(idx.tags, entry.tag, m)
+	delete(idx.nodes, m)
+}
+
+func (idx *Index) insert(table map[string]markupSet, key string, m *Markup) {
+	set, ok := table[key]
+	if !ok {
+		set = make(markupSet)
+		table[key] = set
+	}
+
+	set[m] = struct{}{}
+}
+
+func (idx *Index) remove(table map[string]markupSet, key string, m *Markup) {
+	set, ok := table[key]
+	if !ok {
+		return
+	}
+
+	delete(set, m)
+
+	if len(set) == 0 {
+		delete(table, key)
+	}
+}
+
+//==============================================================================
+
+// candidates returns the smallest set of markup from the index which may match
+// the selector, returning false if the selector can not be answered by the
+// index.
+func (idx *Index) candidates(sel *Selector) (markupSet, bool) {
+	var best markupSet
+	var found bool
+
+	use := func(set markupSet) {
+		if !found || len(set) < len(best) {
+			best = set
+			found = true
+		}
+	}
+
+	if sel.ID != "" {
+		use(idx.ids[sel.ID])
+	}
+
+	if sel.Tag != "" {
+		use(idx.tags[sel.Tag])
+	}
+
+	for _, class := range sel.Classes {
+		use(idx.classes[class])
+	}
+
+	// attributes are only checked when no class is required by the selector
+	// and styles are not indexed.
+	if sel.AttrName != "" && sel.AttrName != "style" && sel.Classes == nil {
+		use(idx.attrs[sel.AttrName])
+	}
+
+	return best, found
+}
+
+// within returns the markup of the set which are descendants of the root in
+// document order.
+func (idx *Index) within(root *Markup, set markupSet) []*Markup {
+	var found []*Markup
+
+	for item := range set {
+		if item != root && isDescendant(root, item) {
+			found = append(found, item)
+		}
+	}
+
+	sortDocumentOrder(found)
+	return found
+}
+
+// ordered returns the set of markup in document order.
+func (idx *Index) ordered(set markupSet) []*Markup {
+	found := make([]*Markup, 0, len(set))
+	for item := range set {
+		found = append(found, item)
+	}
+
+	sortDocumentOrder(found)
+	return found
+}
+
+// isDescendant returns true/false if the markup is the root or one of its
+// descendants.
+func isDescendant(root *Markup, m *Markup) bool {
+	for node := m; node != nil; node = node.parent {
+		if node == root {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortDocumentOrder sorts the giving markup in the order they appear in their
+// tree, where parents come before their children.
+func sortDocumentOrder(items []*Markup) {
+	if len(items) < 2 {
+		return
+	}
+
+	positions := make(map[*Markup]int)
+	paths := make(map[*Markup][]int, len(items))
+
+	for _, item := range items {
+		var path []int
+
+		for node := item; node.parent != nil; node = node.parent {
+			if _, ok := positions[node]; !ok {
+				for index, child := range node.parent.children {
+					positions[child] = index
+				}
+			}
+
+			path = append(path, positions[node])
+		}
+
+		// reverse the path so it starts from the top most parent.
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+
+		paths[item] = path
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		first, second := paths[items[i]], paths[items[j]]
+
+		for n := 0; n < len(first) && n < len(second); n++ {
+			if first[n] != second[n] {
+				return first[n] < second[n]
+			}
+		}
+
+		return len(first) < len(second)
+	})
+}
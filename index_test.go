@@ -0,0 +1,87 @@
+package trees_test
+
+import (
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+func TestIndexedQueries(t *testing.T) {
+	markup := `
+    <div class="wrapper" aria="wrapper-div">
+      <section id="header" class="section"></section>
+      <section id="menu" class="section"></section>
+      <section id="content" class="section main-section">
+        <a rel="delay" href="#delay">Delay</a>
+      </section>
+    </div>
+  `
+
+	plain := trees.ParseAsRoot("section.tree-house#house", markup)
+	tree := trees.ParseAsRoot("section.tree-house#house", markup)
+	index := trees.NewIndex(tree)
+
+	for _, sel := range []string{"section.section", "section", "a[rel=delay]", "div[aria]", ".main-section", "#menu"} {
+		expected := trees.Query.QueryAll(plain, sel)
+		found := trees.Query.QueryAll(tree, sel)
+
+		if len(found) != len(expected) {
+			t.Fatalf("\t%s\t  Should have found %d elements with %q from index: %d", failed, len(expected), sel, len(found))
+		}
+
+		for n, item := range found {
+			if item.Name() != expected[n].Name() || item.HTML() == "" {
+				t.Fatalf("\t%s\t  Should have found elements in document order for %q", failed, sel)
+			}
+		}
+	}
+	t.Logf("\t%s\t  Should have found same elements from index as linear queries", success)
+
+	if len(trees.Query.QueryAll(tree, ".sect")) != 0 || len(trees.Query.QueryAll(plain, ".sect")) != 0 || len(index.WithClass("main-section")) != 1 {
+		t.Fatalf("\t%s\t  Should have matched class names exactly", failed)
+	}
+	t.Logf("\t%s\t  Should have matched class names exactly", success)
+
+	if item := trees.Query.Query(tree, "#content"); item == nil || len(index.WithID("content")) != 1 {
+		t.Fatalf("\t%s\t  Should have found element with id 'content'", failed)
+	}
+	t.Logf("\t%s\t  Should have found element with id 'content'", success)
+
+	footer := trees.NewMarkup("footer", false)
+	trees.NewAttr("id", "footer").Apply(footer)
+	tree.AddChild(footer)
+
+	if item := trees.Query.Query(tree, "footer#footer"); item != footer {
+		t.Fatalf("\t%s\t  Should have indexed newly added element", failed)
+	}
+	t.Logf("\t%s\t  Should have indexed newly added element", success)
+
+	trees.ReplaceAttribute(footer, "id", "bottom")
+	if item := trees.Query.Query(tree, "#footer"); item != nil {
+		t.Fatalf("\t%s\t  Should have removed old id from index", failed)
+	}
+
+	if item := trees.Query.Query(tree, "#bottom"); item != footer {
+		t.Fatalf("\t%s\t  Should have indexed replaced id", failed)
+	}
+	t.Logf("\t%s\t  Should have indexed replaced id", success)
+
+	footer.Remove()
+	tree.Clean()
+
+	if len(index.WithTag("footer")) != 0 || footer.Index() != nil {
+		t.Fatalf("\t%s\t  Should have dropped cleaned element from index", failed)
+	}
+	t.Logf("\t%s\t  Should have dropped cleaned element from index", success)
+
+	if len(trees.ElementsWithTag(tree, "a")) != 1 || len(trees.ElementsWithAttr(tree, "rel", "del")) != 1 {
+		t.Fatalf("\t%s\t  Should have found elements by tag and attribute from index", failed)
+	}
+	t.Logf("\t%s\t  Should have found elements by tag and attribute from index", success)
+
+	index.Detach()
+	if tree.Index() != nil || trees.Query.Query(tree, "#menu") == nil {
+		t.Fatalf("\t%s\t  Should have detached index and fallen back to linear queries", failed)
+	}
+	t.Logf("\t%s\t  Should have detached index and fallen back to linear queries", success)
+}
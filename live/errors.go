@@ -0,0 +1,27 @@
+package live
+
+import "errors"
+
+// ErrBadHandshake is returned when a websocket handshake is invalid.
+var ErrBadHandshake = errors.New("Invalid websocket handshake")
+
+// ErrBadScheme is returned when dialing a url whose scheme is not ws.
+var ErrBadScheme = errors.New("Websocket url must use the ws scheme")
+
+// ErrNotHijacker is returned when the http.ResponseWriter can not be hijacked
+// for a websocket connection.
+var ErrNotHijacker = errors.New("ResponseWriter does not implement http.Hijacker")
+
+// ErrBadFrame is returned when a websocket frame violates the protocol.
+var ErrBadFrame = errors.New("Invalid websocket frame")
+
+// ErrMessageTooLarge is returned when a websocket message exceeds the
+// maximum message size of the connection.
+var ErrMessageTooLarge = errors.New("Websocket message too large")
+
+// ErrClosed is returned when writing to a closed websocket connection.
+var ErrClosed = errors.New("Websocket connection closed")
+
+// ErrTooManySessions is returned when a Handler already has the maximum
+// number of sessions.
+var ErrTooManySessions = errors.New("Too many live view sessions")
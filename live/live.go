@@ -0,0 +1,623 @@
+// Package live provides live views, server rendered trees kept interactive
+// through a websocket per page where client events are delivered to the
+// tree's handlers and the changes of the re-rendered tree are pushed back to
+// the browser as patches, and streams of the patches of read only trees sent
+// as server-sent events.
+package live
+
+import (
+	"crypto/rand"
+	// embeds the live view script.
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/client"
+	"github.com/gu-io/trees/events"
+)
+
+// script contains the live view script.
+//
+//go:embed live.js
+var script string
+
+// DefaultExpiry defines the default duration a session is kept without a
+// connection, waiting for the page to connect or reconnect.
+const DefaultExpiry = time.Minute
+
+// DefaultConnectExpiry defines the default duration within which the page of
+// a new session must connect to it before it is closed.
+const DefaultConnectExpiry = 10 * time.Second
+
+// DefaultMaxSessions defines the default maximum number of sessions of a
+// Handler.
+const DefaultMaxSessions = 1000
+
+// DefaultPingInterval defines the default interval of the pings sent to the
+// connection of a session, which is dropped if nothing is read from it for
+// twice the interval.
+const DefaultPingInterval = 30 * time.Second
+
+// Patch defines the replacement of the markup with the uid by its html.
+type Patch struct {
+	UID  string `json:"uid"`
+	HTML string `json:"html"`
+}
+
+// Message defines the json messages sent to the browser, either a "render"
+// of the whole session tree sent on every connection, the "patch"es of the
+// changes of a re-render, or the "error"s of a rejected batch of events. All
+// contain the bindings of all events of the tree. Connections to unknown or
+// expired sessions get a single "expired" message, upon which the page must
+// be reloaded to start a new session.
+type Message struct {
+	Type    string               `json:"type"`
+	Session string               `json:"session"`
+	HTML    string               `json:"html,omitempty"`
+	Patches []Patch              `json:"patches,omitempty"`
+	Errors  []events.IngestError `json:"errors,omitempty"`
+	Events  []trees.EventJSON    `json:"events"`
+}
+
+// Handler defines a http.Handler serving live views. Plain GET requests get
+// the initial render of a new session within the page returned by Layout,
+// while websocket requests connect to the session of the page. Each session
+// mounts its own instance of the component returned by Component and keeps
+// it until Expiry has passed without a connection, or until ConnectExpiry has
+// passed if the page never connected. Pages are served with a new session
+// only while the handler has less than MaxSessions sessions, else they get a
+// 503 response.
+//
+// Connections are pinged every PingInterval and dropped once nothing was read
+// from them for twice the interval. Websocket requests are only accepted if
+// CheckOrigin returns true, which defaults to accepting requests without an
+// Origin header or whose Origin matches the host of the request.
+type Handler struct {
+	Component     func() trees.Component
+	Expiry        time.Duration
+	ConnectExpiry time.Duration
+	MaxSessions   int
+	PingInterval  time.Duration
+	CheckOrigin   func(r *http.Request) bool
+	Layout        func(content string, scripts string) string
+
+	ml       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewHandler returns a new Handler mounting the components returned by the
+// function.
+func NewHandler(component func() trees.Component) *Handler {
+	return &Handler{
+		Component:     component,
+		Expiry:        DefaultExpiry,
+		ConnectExpiry: DefaultConnectExpiry,
+		MaxSessions:   DefaultMaxSessions,
+		PingInterval:  DefaultPingInterval,
+		CheckOrigin:   SameOrigin,
+		Layout:        DefaultLayout,
+		sessions:      make(map[string]*Session),
+	}
+}
+
+// DefaultLayout returns a html page with the content and scripts in its body.
+func DefaultLayout(content string, scripts string) string {
+	return "<!doctype html>\n<html>\n<head><meta charset=\"utf-8\"></head>\n<body>\n" + content + "\n" + scripts + "\n</body>\n</html>\n"
+}
+
+// ServeHTTP serves the initial render of a new session or connects a
+// websocket to a session.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if IsWebSocket(r) {
+		h.serveSocket(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	s, err := h.newSession()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	s.ml.Lock()
+	content := trees.SimpleElementWriter.Print(s.root)
+	bindings := trees.SimpleElementWriter.Print(client.Script(s.root))
+	s.record()
+	s.ml.Unlock()
+
+	scripts := bindings + "\n<script>" + client.NewHandler("").Script() + "</script>\n<script>" + script + "</script>"
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, h.Layout(content, scripts))
+}
+
+// Session returns the session with the id, if any.
+func (h *Handler) Session(id string) (*Session, bool) {
+	h.ml.Lock()
+	defer h.ml.Unlock()
+
+	s, ok := h.sessions[id]
+	return s, ok
+}
+
+// Len returns the number of sessions of the handler.
+func (h *Handler) Len() int {
+	h.ml.Lock()
+	defer h.ml.Unlock()
+
+	return len(h.sessions)
+}
+
+// SameOrigin returns true/false if the request has no Origin header or if
+// its Origin matches the host of the request.
+func SameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	target, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(target.Host, r.Host)
+}
+
+// serveSocket connects the websocket to the session of the request and
+// delivers its messages until it is closed or stops answering pings. Sockets
+// of unknown sessions get an "expired" message and are closed.
+func (h *Handler) serveSocket(w http.ResponseWriter, r *http.Request) {
+	check := h.CheckOrigin
+	if check == nil {
+		check = SameOrigin
+	}
+
+	if !check(r) {
+		http.Error(w, "Origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	id := r.URL.Query().Get("session")
+	s, ok := h.Session(id)
+
+	conn, err := Upgrade(w, r)
+	if err != nil {
+		return
+	}
+
+	if !ok {
+		data, _ := json.Marshal(Message{Type: "expired", Session: id, Events: []trees.EventJSON{}})
+		conn.WriteMessage(data)
+		conn.Close()
+		return
+	}
+
+	interval := h.PingInterval
+	if interval <= 0 {
+		interval = DefaultPingInterval
+	}
+
+	alive := func() {
+		conn.SetReadDeadline(time.Now().Add(2 * interval))
+	}
+
+	alive()
+	conn.OnPong = func([]byte) { alive() }
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go ping(conn, interval, done)
+
+	if err := s.attach(conn); err != nil {
+		conn.Close()
+		s.detach(conn, h.Expiry)
+		return
+	}
+
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+
+		alive()
+		s.deliver(data)
+	}
+
+	conn.Close()
+	s.detach(conn, h.Expiry)
+}
+
+// ping pings the connection every interval until done is closed or a ping
+// fails.
+func ping(conn *Conn, interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.Ping(nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// newSession returns a new session mounting a new instance of the component,
+// which expires if not connected within the handler's connect expiry, or
+// ErrTooManySessions if the handler has its maximum number of sessions.
+func (h *Handler) newSession() (*Session, error) {
+	if h.full() {
+		return nil, ErrTooManySessions
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	s := &Session{
+		ID:         hex.EncodeToString(id),
+		dispatcher: trees.NewDispatcher(),
+		root:       trees.NewMarkup("div", false),
+		mount:      trees.NewMount(h.Component()),
+		state:      make(map[string]nodeState),
+	}
+
+	s.remove = func() {
+		h.ml.Lock()
+		delete(h.sessions, s.ID)
+		h.ml.Unlock()
+	}
+
+	trees.NewAttr("data-live-session", s.ID).Apply(s.root)
+	s.dispatcher.Apply(s.root)
+
+	// debounced handlers change the tree after the events were delivered.
+	s.dispatcher.UseRunner(func(call func()) {
+		s.Update(call)
+	})
+	s.mount.Apply(s.root)
+
+	h.ml.Lock()
+
+	// other sessions may have been added while the component was mounted.
+	if h.maxSessions() <= len(h.sessions) {
+		h.ml.Unlock()
+
+		s.mount.Unmount()
+		s.root.Empty()
+		return nil, ErrTooManySessions
+	}
+
+	h.sessions[s.ID] = s
+	h.ml.Unlock()
+
+	expiry := h.ConnectExpiry
+	if expiry <= 0 {
+		expiry = DefaultConnectExpiry
+	}
+
+	s.detach(nil, expiry)
+
+	return s, nil
+}
+
+// full returns true/false if the handler has its maximum number of sessions.
+func (h *Handler) full() bool {
+	h.ml.Lock()
+	defer h.ml.Unlock()
+
+	return h.maxSessions() <= len(h.sessions)
+}
+
+// maxSessions returns the maximum number of sessions of the handler.
+func (h *Handler) maxSessions() int {
+	if h.MaxSessions <= 0 {
+		return DefaultMaxSessions
+	}
+
+	return h.MaxSessions
+}
+
+//==============================================================================
+
+// Session defines the state of a live view, with the tree of its component
+// mounted within a root <div data-live-session> and the dispatcher of the
+// events of the tree. All changes to the tree must be made by the handlers of
+// its events or within Update, which also runs the delayed calls of debounced
+// handlers. Handlers changing the tree from other goroutines later on must
+// do so within Update.
+type Session struct {
+	ID string
+
+	ml         sync.Mutex
+	root       *trees.Markup
+	mount      *trees.Mount
+	dispatcher *trees.Dispatcher
+	conn       *Conn
+	state      map[string]nodeState
+	expiry     *time.Timer
+	remove     func()
+	closed     bool
+}
+
+// nodeState defines the state of a markup last sent to the browser.
+type nodeState struct {
+	own      string
+	children []string
+}
+
+// Root returns the root markup of the session.
+func (s *Session) Root() *trees.Markup {
+	return s.root
+}
+
+// Mount returns the mount of the session's component.
+func (s *Session) Mount() *trees.Mount {
+	return s.mount
+}
+
+// Dispatcher returns the dispatcher of the session's events.
+func (s *Session) Dispatcher() *trees.Dispatcher {
+	return s.dispatcher
+}
+
+// Update calls the function while holding the lock of the session, then
+// re-renders the component and pushes the changes to the browser. It allows
+// changing the state of the component outside of event handlers.
+func (s *Session) Update(fn func()) error {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if fn != nil {
+		fn()
+	}
+
+	return s.push()
+}
+
+// attach sets the connection of the session, closing the previous one, and
+// sends the full render of the session.
+func (s *Session) attach(conn *Conn) error {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if s.expiry != nil {
+		s.expiry.Stop()
+		s.expiry = nil
+	}
+
+	if s.conn != nil {
+		s.conn.Close()
+	}
+
+	s.conn = conn
+
+	msg := Message{
+		Type:    "render",
+		Session: s.ID,
+		HTML:    trees.SimpleElementWriter.Print(s.root),
+		Events:  client.Bindings(s.root),
+	}
+
+	s.record()
+	return s.send(msg)
+}
+
+// detach removes the connection from the session, if still its connection,
+// and closes the session once the expiry passes without a new connection.
+func (s *Session) detach(conn *Conn, expiry time.Duration) {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if s.conn != conn || s.closed {
+		return
+	}
+
+	s.conn = nil
+
+	if expiry <= 0 {
+		expiry = DefaultExpiry
+	}
+
+	s.expiry = time.AfterFunc(expiry, s.close)
+}
+
+// close unmounts the component of the session and removes it from its
+// handler, unless connected again.
+func (s *Session) close() {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if s.conn != nil || s.closed {
+		return
+	}
+
+	s.closed = true
+	s.remove()
+	s.mount.Unmount()
+	s.root.Empty()
+}
+
+// deliver dispatches the events of the payload, either a single event or a
+// json array of events as sent by the client runtime, then re-renders the
+// component and pushes the changes. Events are validated as done by
+// events.IngestHandler, a batch with invalid events being rejected as a whole
+// with an "error" message.
+func (s *Session) deliver(data []byte) {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	batch, err := events.DecodeBatch(data)
+	if err != nil {
+		s.reject([]events.IngestError{{Index: -1, Code: events.ErrCodeMalformed, Message: err.Error()}})
+		return
+	}
+
+	broadcasts, errs := events.Validate(s.root, batch)
+	if len(errs) != 0 {
+		s.reject(errs)
+		return
+	}
+
+	for _, evm := range broadcasts {
+		s.dispatcher.Dispatch(evm)
+	}
+
+	s.push()
+}
+
+// reject sends the errors of a rejected batch of events.
+func (s *Session) reject(errs []events.IngestError) error {
+	return s.send(Message{Type: "error", Session: s.ID, Errors: errs, Events: client.Bindings(s.root)})
+}
+
+// push re-renders the component and sends the patches of the changes since
+// the last render sent, if any.
+func (s *Session) push() error {
+	if s.closed {
+		return ErrClosed
+	}
+
+	s.mount.Update()
+	s.root.Clean()
+
+	patches := s.diff()
+	s.record()
+
+	if len(patches) == 0 || s.conn == nil {
+		return nil
+	}
+
+	return s.send(Message{Type: "patch", Session: s.ID, Patches: patches, Events: client.Bindings(s.root)})
+}
+
+// send writes the message to the connection of the session, if any.
+func (s *Session) send(msg Message) error {
+	if s.conn == nil {
+		return nil
+	}
+
+	if msg.Events == nil {
+		msg.Events = []trees.EventJSON{}
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.conn.WriteMessage(data)
+}
+
+// diff returns the patches replacing the outermost markups of the tree whose
+// own state or children changed since the last recorded render. Markups with
+// changed text children are replaced as text nodes can not be patched alone.
+func (s *Session) diff() []Patch {
+	var patches []Patch
+
+	var walk func(m *trees.Markup)
+	walk = func(m *trees.Markup) {
+		prev, ok := s.state[m.UID()]
+		if !ok || prev.own != ownState(m) || !sameChildren(prev.children, m, s.state) {
+			patches = append(patches, Patch{UID: m.UID(), HTML: trees.SimpleElementWriter.Print(m)})
+			return
+		}
+
+		for _, child := range m.Children() {
+			if child.Name() != "text" {
+				walk(child)
+			}
+		}
+	}
+
+	walk(s.root)
+
+	return patches
+}
+
+// record records the state of the tree as sent to the browser.
+func (s *Session) record() {
+	state := make(map[string]nodeState, len(s.state))
+
+	var walk func(m *trees.Markup)
+	walk = func(m *trees.Markup) {
+		var children []string
+
+		for _, child := range m.Children() {
+			children = append(children, child.UID())
+			walk(child)
+		}
+
+		state[m.UID()] = nodeState{own: ownState(m), children: children}
+	}
+
+	walk(s.root)
+	s.state = state
+}
+
+// ownState returns the state of the markup without its children.
+func ownState(m *trees.Markup) string {
+	var state strings.Builder
+
+	state.WriteString(m.Name())
+	state.WriteString("\x00")
+	state.WriteString(m.ID)
+	state.WriteString("\x00")
+	state.WriteString(m.TextContent())
+
+	for _, attr := range m.Attributes() {
+		name, value := attr.Render()
+		state.WriteString("\x00a:" + name + "=" + value)
+	}
+
+	for _, style := range m.Styles() {
+		name, value := style.Render()
+		state.WriteString("\x00s:" + name + "=" + value)
+	}
+
+	return state.String()
+}
+
+// sameChildren returns true/false if the children of the markup have the
+// uids recorded, with their text children unchanged.
+func sameChildren(uids []string, m *trees.Markup, state map[string]nodeState) bool {
+	children := m.Children()
+	if len(children) != len(uids) {
+		return false
+	}
+
+	for index, child := range children {
+		if child.UID() != uids[index] {
+			return false
+		}
+
+		if child.Name() == "text" && state[child.UID()].own != ownState(child) {
+			return false
+		}
+	}
+
+	return true
+}
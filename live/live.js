@@ -0,0 +1,104 @@
+// The live view script connects the <div data-live-session> root of the page
+// to its session through a websocket, sending the events of the trees client
+// runtime over it and applying the "render" and "patch" messages of the
+// server, reconnecting with a full render when the connection drops and
+// reloading the page once its session expired.
+(function (window, document) {
+	"use strict";
+
+	var trees = window.trees;
+	var root = document.querySelector("[data-live-session]");
+	if (!trees || !root) {
+		return;
+	}
+
+	var session = root.getAttribute("data-live-session");
+	var socket = null;
+	var queue = [];
+	var delay = 250;
+
+	// fragment returns the first element of the html.
+	function fragment(html) {
+		var template = document.createElement("template");
+		template.innerHTML = html;
+		return template.content.firstElementChild;
+	}
+
+	// replace replaces the element with the element of the html.
+	function replace(el, html) {
+		var next = fragment(html);
+		if (el && next) {
+			el.parentNode.replaceChild(next, el);
+		}
+
+		return next;
+	}
+
+	function url() {
+		var scheme = window.location.protocol === "https:" ? "wss://" : "ws://";
+		return scheme + window.location.host + window.location.pathname + "?session=" + encodeURIComponent(session);
+	}
+
+	function flush() {
+		while (socket && socket.readyState === 1 && queue.length) {
+			socket.send(JSON.stringify(queue.shift()));
+		}
+	}
+
+	function receive(msg) {
+		if (msg.type === "expired") {
+			window.location.reload();
+			return;
+		}
+
+		if (msg.session) {
+			session = msg.session;
+		}
+
+		if (msg.type === "render") {
+			root = replace(root, msg.html) || root;
+		} else if (msg.type === "error" && window.console) {
+			window.console.warn("trees: events rejected", msg.errors);
+		} else if (msg.type === "patch") {
+			for (var i = 0; msg.patches && i < msg.patches.length; i++) {
+				var patch = msg.patches[i];
+				if (root.getAttribute("uid") === patch.uid) {
+					root = replace(root, patch.html) || root;
+					continue;
+				}
+
+				replace(root.querySelector("[uid='" + patch.uid + "']"), patch.html);
+			}
+		}
+
+		// once bindings which fired stay limited while their event exists.
+		trees.sync(msg.events);
+	}
+
+	function connect() {
+		socket = new window.WebSocket(url());
+
+		socket.onopen = function () {
+			delay = 250;
+			flush();
+		};
+
+		socket.onmessage = function (ev) {
+			receive(JSON.parse(ev.data));
+		};
+
+		socket.onclose = function () {
+			socket = null;
+			setTimeout(connect, delay);
+			delay = Math.min(delay * 2, 10000);
+		};
+	}
+
+	// events are queued while disconnected and sent once connected.
+	trees.transport = function (payload) {
+		queue.push(payload);
+		flush();
+	};
+
+	connect();
+})(window, document);
@@ -0,0 +1,295 @@
+package live_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+	"github.com/gu-io/trees/live"
+	"github.com/influx6/faux/tests"
+)
+
+type counter struct {
+	count int
+}
+
+func (c *counter) Render() *trees.Markup {
+	root := trees.NewMarkup("section", false)
+
+	title := trees.NewMarkup("h1", false)
+	trees.NewText("Counter").Apply(title)
+	title.Apply(root)
+
+	button := trees.NewMarkup("button", false)
+	trees.NewText("%d", c.count).Apply(button)
+	events.ClickEvent(func(ev *trees.MouseEvent) {
+		c.count++
+	}).Apply(button)
+	button.Apply(root)
+
+	return root
+}
+
+func read(conn *live.Conn) live.Message {
+	var msg live.Message
+
+	data, err := conn.ReadMessage()
+	if err != nil {
+		tests.Failed("Should have read websocket message: %+q", err)
+	}
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		tests.Failed("Should have decoded websocket message: %+q", err)
+	}
+
+	return msg
+}
+
+func TestLiveView(t *testing.T) {
+	handler := live.NewHandler(func() trees.Component { return &counter{} })
+	handler.Expiry = 50 * time.Millisecond
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	if err != nil {
+		tests.Failed("Should have fetched initial render: %+q", err)
+	}
+
+	page, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	matches := regexp.MustCompile(`data-live-session="([0-9a-f]+)"`).FindSubmatch(page)
+	if res.StatusCode != http.StatusOK || matches == nil || !strings.Contains(string(page), "<button") || !strings.Contains(string(page), "data-trees-events") {
+		tests.Failed("Should have served initial render with session: %s", page)
+	}
+	tests.Passed("Should have served initial render with session")
+
+	session := string(matches[1])
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/?session=" + session
+
+	conn, err := live.Dial(wsURL, nil)
+	if err != nil {
+		tests.Failed("Should have connected websocket: %+q", err)
+	}
+
+	render := read(conn)
+	if render.Type != "render" || render.Session != session || len(render.Events) != 1 || !strings.Contains(render.HTML, ">0</button>") {
+		tests.Failed("Should have sent full render of session: %+v", render)
+	}
+	tests.Passed("Should have sent full render of session")
+
+	click := render.Events[0]
+	target := regexp.MustCompile(`<button[^>]* uid="([^"]+)"`).FindStringSubmatch(render.HTML)
+	if target == nil {
+		tests.Failed("Should have rendered button with uid: %s", render.HTML)
+	}
+
+	payload := fmt.Sprintf(`[{"event":%q,"event_id":%q,"type":"click","target":%q,"event_object":{"type":"click","clientX":2}}]`, click.EventName, click.EventID, target[1])
+	if err := conn.WriteMessage([]byte(payload)); err != nil {
+		tests.Failed("Should have sent event: %+q", err)
+	}
+
+	patch := read(conn)
+	if patch.Type != "patch" || len(patch.Patches) != 1 || patch.Patches[0].UID != target[1] || !strings.Contains(patch.Patches[0].HTML, ">1</button>") {
+		tests.Failed("Should have patched only the changed button: %+v", patch)
+	}
+	tests.Passed("Should have patched only the changed button")
+
+	if len(patch.Events) != 1 || patch.Events[0].EventID != click.EventID {
+		tests.Failed("Should have sent event bindings with patch: %+v", patch.Events)
+	}
+	tests.Passed("Should have sent event bindings with patch")
+
+	conn.Close()
+
+	conn, err = live.Dial(wsURL, nil)
+	if err != nil {
+		tests.Failed("Should have reconnected websocket: %+q", err)
+	}
+
+	render = read(conn)
+	if render.Type != "render" || render.Session != session || !strings.Contains(render.HTML, ">1</button>") {
+		tests.Failed("Should have resynced session state on reconnection: %+v", render)
+	}
+	tests.Passed("Should have resynced session state on reconnection")
+
+	conn.Close()
+
+	time.Sleep(200 * time.Millisecond)
+	if _, ok := handler.Session(session); ok {
+		tests.Failed("Should have expired disconnected session")
+	}
+	tests.Passed("Should have expired disconnected session")
+
+	conn, err = live.Dial(wsURL, nil)
+	if err != nil {
+		tests.Failed("Should have connected websocket to expired session: %+q", err)
+	}
+	defer conn.Close()
+
+	if expired := read(conn); expired.Type != "expired" || expired.Session != session || handler.Len() != 0 {
+		tests.Failed("Should have rejected expired session without starting a new one: %+v", expired)
+	}
+	tests.Passed("Should have rejected expired session without starting a new one")
+}
+
+func TestLiveViewSessionLimits(t *testing.T) {
+	handler := live.NewHandler(func() trees.Component { return &counter{} })
+	handler.MaxSessions = 1
+	handler.ConnectExpiry = 50 * time.Millisecond
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	open(server.URL)
+
+	res, err := http.Get(server.URL)
+	if err != nil {
+		tests.Failed("Should have fetched page: %+q", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusServiceUnavailable || handler.Len() != 1 {
+		tests.Failed("Should have refused sessions over the limit: %d", res.StatusCode)
+	}
+	tests.Passed("Should have refused sessions over the limit")
+
+	time.Sleep(200 * time.Millisecond)
+	if handler.Len() != 0 {
+		tests.Failed("Should have expired session never connected")
+	}
+	tests.Passed("Should have expired session never connected")
+}
+
+// open fetches the page of a new session, returning the session id.
+func open(url string) string {
+	res, err := http.Get(url)
+	if err != nil {
+		tests.Failed("Should have fetched initial render: %+q", err)
+	}
+
+	page, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	matches := regexp.MustCompile(`data-live-session="([0-9a-f]+)"`).FindSubmatch(page)
+	if res.StatusCode != http.StatusOK || matches == nil {
+		tests.Failed("Should have served initial render with session: %s", page)
+	}
+
+	return string(matches[1])
+}
+
+func TestUpgrade(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "keep-alive, Upgrade")
+
+	if !live.IsWebSocket(req) {
+		tests.Failed("Should have detected websocket request")
+	}
+	tests.Passed("Should have detected websocket request")
+
+	if _, err := live.Upgrade(rec, req); err != live.ErrBadHandshake || rec.Code != http.StatusBadRequest {
+		tests.Failed("Should have rejected handshake without key and version: %d", rec.Code)
+	}
+	tests.Passed("Should have rejected handshake without key and version")
+}
+
+type search struct {
+	query string
+}
+
+func (s *search) Render() *trees.Markup {
+	root := trees.NewMarkup("form", false)
+
+	input := trees.NewMarkup("input", false)
+	events.InputEvent(func(ev *trees.InputEvent) {
+		s.query = ev.Value
+	}, trees.Debounce(20*time.Millisecond)).Apply(input)
+	input.Apply(root)
+
+	result := trees.NewMarkup("p", false)
+	trees.NewText("%s", s.query).Apply(result)
+	result.Apply(root)
+
+	return root
+}
+
+func TestLiveViewDelivery(t *testing.T) {
+	handler := live.NewHandler(func() trees.Component { return &search{} })
+	handler.PingInterval = 50 * time.Millisecond
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/?session=" + open(server.URL)
+
+	if _, err := live.Dial(wsURL, http.Header{"Origin": {"http://elsewhere.example"}}); err == nil {
+		tests.Failed("Should have rejected websocket from other origin")
+	}
+	tests.Passed("Should have rejected websocket from other origin")
+
+	conn, err := live.Dial(wsURL, http.Header{"Origin": {server.URL}})
+	if err != nil {
+		tests.Failed("Should have connected websocket from same origin: %+q", err)
+	}
+	defer conn.Close()
+
+	render := read(conn)
+	input := render.Events[0]
+
+	payload := fmt.Sprintf(`[{"event":%q,"event_id":%q,"type":"click","event_object":{}},{"event_id":"missing","event_object":{}}]`, input.EventName, input.EventID)
+	if err := conn.WriteMessage([]byte(payload)); err != nil {
+		tests.Failed("Should have sent events: %+q", err)
+	}
+
+	rejected := read(conn)
+	if rejected.Type != "error" || len(rejected.Errors) != 2 || rejected.Errors[0].Code != events.ErrCodeTypeMismatch || rejected.Errors[1].Code != events.ErrCodeUnknownEvent {
+		tests.Failed("Should have reported invalid events to client: %+v", rejected)
+	}
+	tests.Passed("Should have reported invalid events to client")
+
+	if err := conn.WriteMessage([]byte(`{"event_id":`)); err != nil {
+		tests.Failed("Should have sent malformed payload: %+q", err)
+	}
+
+	if malformed := read(conn); malformed.Type != "error" || len(malformed.Errors) != 1 || malformed.Errors[0].Code != events.ErrCodeMalformed {
+		tests.Failed("Should have reported malformed payload to client: %+v", malformed)
+	}
+	tests.Passed("Should have reported malformed payload to client")
+
+	for _, value := range []string{"t", "tr", "tree"} {
+		payload := fmt.Sprintf(`{"event":%q,"event_id":%q,"type":"input","event_object":{"type":"input","value":%q}}`, input.EventName, input.EventID, value)
+		if err := conn.WriteMessage([]byte(payload)); err != nil {
+			tests.Failed("Should have sent input event: %+q", err)
+		}
+	}
+
+	patch := read(conn)
+	if patch.Type != "patch" || len(patch.Patches) != 1 || !strings.Contains(patch.Patches[0].HTML, ">tree</p>") {
+		tests.Failed("Should have pushed changes of debounced handler: %+v", patch)
+	}
+	tests.Passed("Should have pushed changes of debounced handler")
+
+	// the connection is not read while the server pings it, so its pongs
+	// are never sent.
+	time.Sleep(300 * time.Millisecond)
+
+	for {
+		if _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+	tests.Passed("Should have dropped connection not answering pings")
+}
@@ -0,0 +1,260 @@
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gu-io/trees"
+)
+
+// DefaultHistory defines the default number of patches kept by a Stream for
+// clients resuming after a disconnect.
+const DefaultHistory = 256
+
+// DefaultHeartbeat defines the default interval of the comments sent by a
+// Stream to keep idle connections open.
+const DefaultHeartbeat = 15 * time.Second
+
+// streamEvent defines a server-sent event of a Stream.
+type streamEvent struct {
+	id   uint64
+	name string
+	data []byte
+}
+
+// streamMessage defines the json data of the events of a Stream.
+type streamMessage struct {
+	HTML    string  `json:"html,omitempty"`
+	Patches []Patch `json:"patches,omitempty"`
+}
+
+// Stream defines a http.Handler streaming the changes of a tree to its
+// clients as server-sent events, for pages which only display the tree. The
+// components of the tree are marked with the Scheduler of the stream, whose
+// flushes reconcile them within Update of the tree and send the changes as
+// "patch" events with the rendered html of each change and its uid, numbered
+// by increasing event ids.
+//
+// Clients reconnecting with a Last-Event-ID header get the patches they
+// missed, while new clients and clients whose missed patches are no longer
+// within the last History patches get a "render" event with the html of the
+// whole tree. Patches and renders are printed while holding the lock of the
+// tree, so a render always has the id of the last patch it contains.
+type Stream struct {
+	History   int
+	Heartbeat time.Duration
+
+	root      *trees.Markup
+	scheduler *trees.Scheduler
+
+	ml      sync.Mutex
+	last    uint64
+	events  []streamEvent
+	clients map[chan struct{}]struct{}
+
+	once sync.Once
+	done chan struct{}
+}
+
+// NewStream returns a new Stream of the tree of the markup.
+func NewStream(root *trees.Markup) *Stream {
+	s := &Stream{
+		History:   DefaultHistory,
+		Heartbeat: DefaultHeartbeat,
+		root:      root,
+		clients:   make(map[chan struct{}]struct{}),
+		done:      make(chan struct{}),
+	}
+
+	s.scheduler = trees.NewScheduler(s.publish)
+	s.scheduler.UseRunner(func(flush func()) {
+		root.Update(func(*trees.Markup) {
+			flush()
+		})
+	})
+
+	return s
+}
+
+// Scheduler returns the scheduler of the stream, with which the components
+// of the tree must be marked. Its flushes, made with Flush or by its ticker,
+// must not be made within Update of the tree as they lock it themselves.
+func (s *Stream) Scheduler() *trees.Scheduler {
+	return s.scheduler
+}
+
+// publish sends the changes of the patch to the clients of the stream as a
+// single "patch" event. It is called by the flushes of the scheduler, while
+// holding the lock of the tree.
+func (s *Stream) publish(p trees.Patch) {
+	if p.Empty() {
+		return
+	}
+
+	var msg streamMessage
+
+	for _, change := range p.Changes {
+		msg.Patches = append(msg.Patches, Patch{UID: change.UID(), HTML: trees.SimpleElementWriter.Print(change)})
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	s.last++
+	s.events = append(s.events, streamEvent{id: s.last, name: "patch", data: data})
+
+	history := s.History
+	if history <= 0 {
+		history = DefaultHistory
+	}
+
+	if len(s.events) > history {
+		s.events = append([]streamEvent(nil), s.events[len(s.events)-history:]...)
+	}
+
+	for notify := range s.clients {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Len returns the number of clients connected to the stream.
+func (s *Stream) Len() int {
+	s.ml.Lock()
+	defer s.ml.Unlock()
+
+	return len(s.clients)
+}
+
+// Close stops the ticker of the scheduler of the stream, if started, and
+// ends the connections of all clients of the stream.
+func (s *Stream) Close() {
+	s.scheduler.Stop()
+
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// ServeHTTP streams the events of the stream to the client until it
+// disconnects or the stream is closed.
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	notify := make(chan struct{}, 1)
+
+	s.ml.Lock()
+	s.clients[notify] = struct{}{}
+	s.ml.Unlock()
+
+	defer func() {
+		s.ml.Lock()
+		delete(s.clients, notify)
+		s.ml.Unlock()
+	}()
+
+	var heartbeat <-chan time.Time
+	if s.Heartbeat > 0 {
+		ticker := time.NewTicker(s.Heartbeat)
+		defer ticker.Stop()
+
+		heartbeat = ticker.C
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	last, err := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	pending, last := s.since(last, err == nil)
+
+	for {
+		for _, ev := range pending {
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.id, ev.name, ev.data)
+		}
+
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case <-heartbeat:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			pending = nil
+			continue
+		case <-notify:
+		}
+
+		pending, last = s.since(last, true)
+	}
+}
+
+// since returns the events following the event with the id, if resumable,
+// or a render of the tree, with the id of the last event returned.
+func (s *Stream) since(last uint64, resume bool) ([]streamEvent, uint64) {
+	s.ml.Lock()
+
+	if resume && last == s.last {
+		s.ml.Unlock()
+		return nil, last
+	}
+
+	if resume && last < s.last && len(s.events) != 0 && last+1 >= s.events[0].id {
+		var pending []streamEvent
+
+		for _, ev := range s.events {
+			if ev.id > last {
+				pending = append(pending, ev)
+			}
+		}
+
+		s.ml.Unlock()
+		return pending, s.last
+	}
+
+	s.ml.Unlock()
+
+	ev := s.render()
+	return []streamEvent{ev}, ev.id
+}
+
+// render returns a "render" event of the tree with the id of the last patch
+// published, taken while holding the read lock of the tree which the patches
+// are published with.
+func (s *Stream) render() streamEvent {
+	var ev streamEvent
+
+	s.root.View(func(root *trees.Markup) {
+		data, _ := json.Marshal(streamMessage{HTML: trees.SimpleElementWriter.Print(root)})
+
+		s.ml.Lock()
+		ev = streamEvent{id: s.last, name: "render", data: data}
+		s.ml.Unlock()
+	})
+
+	return ev
+}
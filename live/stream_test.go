@@ -0,0 +1,178 @@
+package live_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/live"
+	"github.com/influx6/faux/tests"
+)
+
+type gauge struct {
+	value int
+}
+
+func (g *gauge) Render() *trees.Markup {
+	meter := trees.NewMarkup("meter", false)
+	trees.NewText("%d", g.value).Apply(meter)
+	return meter
+}
+
+type sse struct {
+	id   string
+	name string
+	msg  live.Message
+}
+
+func connect(url string, lastID string) (*http.Response, *bufio.Reader) {
+	req, _ := http.NewRequest("GET", url, nil)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+
+	res, err := (&http.Client{Timeout: 5 * time.Second}).Do(req)
+	if err != nil {
+		tests.Failed("Should have connected to stream: %+q", err)
+	}
+
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/event-stream" {
+		tests.Failed("Should have received event stream: %d", res.StatusCode)
+	}
+
+	return res, bufio.NewReader(res.Body)
+}
+
+func next(r *bufio.Reader) sse {
+	var ev sse
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			tests.Failed("Should have read server-sent event: %+q", err)
+		}
+
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case line == "" && ev.name != "":
+			return ev
+		case strings.HasPrefix(line, "id: "):
+			ev.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			ev.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev.msg)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	root := trees.NewMarkup("main", false)
+	comp := &gauge{}
+	mount := trees.NewMount(comp)
+	mount.Apply(root)
+
+	stream := live.NewStream(root)
+	scheduler := stream.Scheduler()
+
+	server := httptest.NewServer(stream)
+	defer server.Close()
+	defer stream.Close()
+
+	set := func(value int) {
+		root.Update(func(*trees.Markup) {
+			comp.value = value
+		})
+
+		scheduler.Mark(mount)
+		scheduler.Flush()
+	}
+
+	res, body := connect(server.URL, "")
+
+	ev := next(body)
+	if ev.name != "render" || ev.id != "0" || !strings.Contains(ev.msg.HTML, ">0</meter>") {
+		tests.Failed("Should have sent render of tree to new client: %+v", ev)
+	}
+	tests.Passed("Should have sent render of tree to new client")
+
+	set(1)
+
+	ev = next(body)
+	if ev.name != "patch" || ev.id != "1" || len(ev.msg.Patches) != 1 || ev.msg.Patches[0].UID != mount.Markup().UID() || !strings.Contains(ev.msg.Patches[0].HTML, ">1</meter>") {
+		tests.Failed("Should have streamed patch of reconciled tree: %+v", ev)
+	}
+	tests.Passed("Should have streamed patch of reconciled tree")
+
+	res.Body.Close()
+
+	set(2)
+	set(3)
+
+	res, body = connect(server.URL, "1")
+
+	first, second := next(body), next(body)
+	if first.id != "2" || second.id != "3" || !strings.Contains(second.msg.Patches[0].HTML, ">3</meter>") {
+		tests.Failed("Should have resumed after Last-Event-ID: %+v %+v", first, second)
+	}
+	tests.Passed("Should have resumed after Last-Event-ID")
+
+	res.Body.Close()
+
+	stream.History = 1
+	set(4)
+
+	res, body = connect(server.URL, "2")
+
+	ev = next(body)
+	if ev.name != "render" || ev.id != "4" || !strings.Contains(ev.msg.HTML, ">4</meter>") {
+		tests.Failed("Should have sent render when patches are no longer kept: %+v", ev)
+	}
+	tests.Passed("Should have sent render when patches are no longer kept")
+
+	res.Body.Close()
+
+	stop, done := make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for value := 5; ; value++ {
+			select {
+			case <-stop:
+				return
+			default:
+				set(value)
+			}
+		}
+	}()
+
+	// every patch has the id of its value, as has the render containing it.
+	for i := 0; i < 10; i++ {
+		res, body = connect(server.URL, "")
+
+		ev = next(body)
+		if ev.name != "render" || !strings.Contains(ev.msg.HTML, ">"+ev.id+"</meter>") {
+			tests.Failed("Should have sent render matching id of last patch: %+v", ev)
+		}
+
+		res.Body.Close()
+	}
+
+	close(stop)
+	<-done
+	tests.Passed("Should have sent render matching id of last patch")
+
+	rec := httptest.NewRecorder()
+	stream.ServeHTTP(rec, httptest.NewRequest("POST", "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		tests.Failed("Should have rejected non GET request: %d", rec.Code)
+	}
+	tests.Passed("Should have rejected non GET request")
+}
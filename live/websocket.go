@@ -0,0 +1,379 @@
+package live
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID defines the GUID used to compute the accept key of the
+// websocket handshake (RFC 6455, section 1.3).
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// contains the frame opcodes of the websocket protocol.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// DefaultMaxMessageSize defines the default maximum size of the messages read
+// by a Conn.
+const DefaultMaxMessageSize = 1 << 20
+
+// Conn defines a minimal websocket connection (RFC 6455) exchanging text
+// messages, used by the live view sessions and their clients. Pings are
+// answered with pongs and close frames are echoed while reading, pongs being
+// passed to OnPong if set. Reads must be made from a single goroutine while
+// writes are safe for concurrent use.
+type Conn struct {
+	MaxMessageSize int64
+	OnPong         func(data []byte)
+
+	conn   net.Conn
+	br     *bufio.Reader
+	client bool
+
+	wl     sync.Mutex
+	closed bool
+}
+
+// Upgrade upgrades the http request to a websocket connection, replying with
+// a 400 status if the request is not a valid websocket handshake.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if !IsWebSocket(r) || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "Invalid websocket handshake", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "Invalid websocket handshake", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "Websocket not supported", http.StatusInternalServerError)
+		return nil, ErrNotHijacker
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	rw.WriteString("Upgrade: websocket\r\n")
+	rw.WriteString("Connection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n")
+
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Conn{MaxMessageSize: DefaultMaxMessageSize, conn: conn, br: rw.Reader}, nil
+}
+
+// IsWebSocket returns true/false if the request asks for a websocket upgrade.
+func IsWebSocket(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		headerContains(r.Header, "Connection", "upgrade")
+}
+
+// Dial opens a websocket connection to the ws:// url, mostly used to drive
+// live views from tests and Go clients.
+func Dial(rawurl string, header http.Header) (*Conn, error) {
+	target, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Scheme != "ws" {
+		return nil, ErrBadScheme
+	}
+
+	conn, err := net.DialTimeout("tcp", target.Host, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        target,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       target.Host,
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusSwitchingProtocols || res.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		conn.Close()
+		return nil, ErrBadHandshake
+	}
+
+	return &Conn{MaxMessageSize: DefaultMaxMessageSize, conn: conn, br: br, client: true}, nil
+}
+
+// ReadMessage returns the next text or binary message of the connection. It
+// returns io.EOF once the connection is closed by the peer.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	var started bool
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			if c.OnPong != nil {
+				c.OnPong(payload)
+			}
+			continue
+		case opClose:
+			c.writeFrame(opClose, payload)
+			c.conn.Close()
+			return nil, io.EOF
+		case opText, opBinary:
+			if started {
+				return nil, ErrBadFrame
+			}
+
+			started = true
+			message = payload
+		case opContinuation:
+			if !started {
+				return nil, ErrBadFrame
+			}
+
+			message = append(message, payload...)
+		default:
+			return nil, ErrBadFrame
+		}
+
+		if c.MaxMessageSize > 0 && int64(len(message)) > c.MaxMessageSize {
+			c.closeWith(1009)
+			return nil, ErrMessageTooLarge
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+// WriteMessage writes the data as a text message.
+func (c *Conn) WriteMessage(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+// Ping sends a ping frame with the data, which must not exceed 125 bytes.
+func (c *Conn) Ping(data []byte) error {
+	return c.writeFrame(opPing, data)
+}
+
+// SetReadDeadline sets the deadline of the reads of the connection, after
+// which ReadMessage fails with a timeout error.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// Close sends a close frame and closes the connection.
+func (c *Conn) Close() error {
+	return c.closeWith(1000)
+}
+
+// closeWith sends a close frame with the status code and closes the
+// connection.
+func (c *Conn) closeWith(code uint16) error {
+	payload := make([]byte, 2)
+	binary.BigEndian.PutUint16(payload, code)
+
+	c.writeFrame(opClose, payload)
+
+	c.wl.Lock()
+	c.closed = true
+	c.wl.Unlock()
+
+	return c.conn.Close()
+}
+
+// readFrame reads a single frame of the connection.
+func (c *Conn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.br, head[:]); err != nil {
+		return
+	}
+
+	fin = head[0]&0x80 != 0
+	opcode = head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+
+	// clients must mask their frames while servers must not.
+	if head[0]&0x70 != 0 || masked == c.client {
+		err = ErrBadFrame
+		return
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if opcode >= opClose && (length > 125 || !fin) {
+		err = ErrBadFrame
+		return
+	}
+
+	if c.MaxMessageSize > 0 && length > uint64(c.MaxMessageSize) {
+		c.closeWith(1009)
+		err = ErrMessageTooLarge
+		return
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return
+}
+
+// writeFrame writes the payload as a single final frame with the opcode,
+// masked if the connection is a client.
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.wl.Lock()
+	defer c.wl.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	frame := make([]byte, 0, len(payload)+14)
+	frame = append(frame, 0x80|opcode)
+
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+
+	switch length := len(payload); {
+	case length <= 125:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, maskBit|126, byte(length>>8), byte(length))
+	default:
+		var ext [8]byte
+		binary.BigEndian.PutUint64(ext[:], uint64(length))
+		frame = append(frame, maskBit|127)
+		frame = append(frame, ext[:]...)
+	}
+
+	if !c.client {
+		frame = append(frame, payload...)
+	} else {
+		var mask [4]byte
+		rand.Read(mask[:])
+		frame = append(frame, mask[:]...)
+
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+	}
+
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// acceptKey returns the Sec-WebSocket-Accept value for the key.
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerContains returns true/false if the comma separated values of the
+// header contain the token.
+func headerContains(header http.Header, name string, token string) bool {
+	for _, value := range header[http.CanonicalHeaderKey(name)] {
+		for _, item := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
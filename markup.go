@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"html/template"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/gu-io/trees/css"
 	"github.com/russross/blackfriday"
@@ -21,6 +23,7 @@ type Markup struct {
 
 	uid           string
 	hash          string
+	key           string
 	tagname       string
 	textContent   string
 	idSelector    string
@@ -32,6 +35,24 @@ type Markup struct {
 	attrs    []Property
 	morphers []Morpher
 	parent   *Markup
+
+	observers  []*MutationObserver
+	indexed    *Index
+	history    *History
+	context    map[interface{}]interface{}
+	boundary   *ErrorBoundary
+	dispatcher *Dispatcher
+	failure    *RenderError
+	rendering  int32
+	async      *asyncState
+	bindings   []*signalBinding
+	shared     bool
+
+	rw       sync.RWMutex
+	snap     sync.Mutex
+	snapshot *Markup
+	source   *Node
+	frozen   bool
 }
 
 // NewText returns a new Text instance element
@@ -61,7 +82,7 @@ func MarkdownTemplate(tml string, bind interface{}) *Markup {
 
 	// if error occured, return a <error> tag with error details.
 	if err != nil {
-		return ParseFirstOrMakeRoot("<error>" + err.Error() + "</error>")
+		return errorMarkup(err)
 	}
 
 	return ParseFirstOrMakeRoot(processed)
@@ -70,6 +91,8 @@ func MarkdownTemplate(tml string, bind interface{}) *Markup {
 // CSSStylesheet provides a function that takes style rules which returns a stylesheet embeded into
 // the provided element parent and is built on the gu/css package which collects
 // necessary details from its parent to only target where it gets mounted.
+// If bind is a func(*Markup) interface{} (e.g ContextBind), it is called with
+// the style markup on every render to retrieve the data for the rules.
 func CSSStylesheet(styles interface{}, bind interface{}, ext *css.Rule, plain bool) *Markup {
 	var rs *css.Rule
 
@@ -96,9 +119,14 @@ func CSSStylesheet(styles interface{}, bind interface{}, ext *css.Rule, plain bo
 	content.allowStyles = false
 	content.allowEvents = false
 	content.textContentFn = func(owner *Markup) string {
-		sheet, err := rs.Stylesheet(bind, owner.IDSelector(true))
+		data := bind
+		if binder, ok := bind.(func(*Markup) interface{}); ok {
+			data = binder(owner)
+		}
+
+		sheet, err := rs.Stylesheet(data, owner.IDSelector(true))
 		if err != nil {
-			return err.Error()
+			return renderError(owner, err)
 		}
 
 		return sheet.String()
@@ -127,10 +155,38 @@ func NewMarkup(tag string, autoClose bool) *Markup {
 
 // Empty resets the elements children list as 0 length
 func (e *Markup) Empty() {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+	defer e.group()()
+
+	children := e.children
+	styles := e.styles
+
+	for index := range e.events {
+		e.events[index].unsubscribe()
+	}
+
+	for _, child := range children {
+		child.unsubscribeEvents(true)
+	}
+
 	e.children = nil
 	e.events = nil
 	e.styles = nil
 	e.morphers = nil
+
+	// deliver removals from the last so each index stays valid when replayed.
+	for index := len(children) - 1; index >= 0; index-- {
+		e.notify(MutationRecord{Type: ChildRemoved, Target: e, Child: children[index], Index: index})
+	}
+
+	for _, style := range styles {
+		name, value := style.Render()
+		e.notify(MutationRecord{Type: StyleRemoved, Target: e, Name: name, OldValue: value})
+	}
 }
 
 // MarkupJSON defines a struct which contains the giving events and
@@ -189,6 +245,12 @@ func (e *Markup) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON the provided data and adds the giving children into the giving root.
 func (e *Markup) UnmarshalJSON(data []byte) error {
+	if e.frozen {
+		return nil
+	}
+
+	e.touch()
+
 	parsed := ParseTree(string(data))
 
 	if len(parsed) == 1 {
@@ -250,11 +312,81 @@ type Properties interface {
 	Events() []Event
 }
 
-// AddEvent adds an event into the event list for this element.
+// AddEvent adds an event into the event list for this element, subscribing
+// its handler for the element.
 func (e *Markup) AddEvent(ev Event) {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
+	if ev.Handler != nil {
+		ev.Tree = e
+		ev.Remove = nil
+		ev.limiter = newEventLimiter(&ev)
+
+		if !e.shared {
+			ev.subscribe()
+		}
+	}
+
 	e.events = append(e.events, ev)
 }
 
+// subscribeEvents subscribes the events with handlers of the markup and its
+// descendants which are not subscribed, or whose subscription no longer
+// matches their dispatcher or id, and restores their signal bindings. Events
+// of a clone still sharing the uid of its original are left unsubscribed.
+func (e *Markup) subscribeEvents() {
+	for _, binding := range e.bindings {
+		binding.signal.attach(binding)
+	}
+
+	for index := range e.events {
+		ev := e.events[index]
+		if e.shared || ev.Handler == nil || (ev.Remove != nil && !ev.stale()) {
+			continue
+		}
+
+		ev.Tree = e
+		ev.resubscribe()
+		e.events[index] = ev
+	}
+
+	for _, child := range e.children {
+		child.subscribeEvents()
+	}
+}
+
+// unsubscribeEvents removes the subscriptions of the events and the signal
+// bindings of the markup and its descendants if deep is true.
+func (e *Markup) unsubscribeEvents(deep bool) {
+	for _, binding := range e.bindings {
+		binding.signal.detach(binding)
+	}
+
+	for index := range e.events {
+		e.events[index].unsubscribe()
+	}
+
+	if !deep {
+		return
+	}
+
+	for _, child := range e.children {
+		child.unsubscribeEvents(true)
+	}
+}
+
+// Release unsubscribes the events and releases the signal bindings of the
+// markup and its descendants. It must be called on a tree which is dropped
+// without being cleaned, emptied or replaced through Reconcile (e.g a root no
+// longer rendered), else its handlers and bindings stay subscribed.
+func (e *Markup) Release() {
+	e.unsubscribeEvents(true)
+}
+
 // EachEvent iterates all events from this giving root down with all childrens
 // allowing the callback to process the events has needed.
 func (e *Markup) EachEvent(fn func(*Event, *Markup)) {
@@ -279,7 +411,14 @@ func (e *Markup) Styles() []Property {
 
 // AddStyle adds a property to the style property list.
 func (e *Markup) AddStyle(p Property) {
+	if e.frozen {
+		return
+	}
+
 	e.styles = append(e.styles, p)
+
+	name, value := p.Render()
+	e.notify(MutationRecord{Type: StyleSet, Target: e, Name: name, Value: value, Added: true})
 }
 
 // Attributes return the internal attribute list of the element
@@ -289,7 +428,14 @@ func (e *Markup) Attributes() []Property {
 
 // AddAttribute adds a property to the attribute property list.
 func (e *Markup) AddAttribute(p Property) {
+	if e.frozen {
+		return
+	}
+
 	e.attrs = append(e.attrs, p)
+
+	name, value := p.Render()
+	e.notify(MutationRecord{Type: AttributeSet, Target: e, Name: name, Value: value, Added: true})
 }
 
 //==============================================================================
@@ -299,11 +445,34 @@ func (e *Markup) EventID() string {
 	return fmt.Sprintf("%s[uid='%s']", strings.ToLower(e.Name()), e.UID())
 }
 
+// Parent returns the parent of the element, if any.
+func (e *Markup) Parent() *Markup {
+	return e.parent
+}
+
 // Name returns the tag name of the element
 func (e *Markup) Name() string {
 	return e.tagname
 }
 
+// Key returns the key of the Element used to match it against the old
+// children of its parent during Reconcile.
+func (e *Markup) Key() string {
+	return e.key
+}
+
+// SetKey sets the key of the Element. Children with keys are reconciled against
+// the old child with the same key regardless of their position.
+func (e *Markup) SetKey(key string) {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
+	e.key = key
+}
+
 // UID returns the current uid of the Element
 func (e *Markup) UID() string {
 	return e.uid
@@ -325,6 +494,12 @@ type Morphers interface {
 
 // AddMorpher adds the provided morphers into the elements lists.
 func (e *Markup) AddMorpher(m ...Morpher) {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
 	e.morphers = append(e.morphers, m...)
 }
 
@@ -336,8 +511,28 @@ func (e *Markup) Morphers() []Morpher {
 // ApplyMorphers calls all elemental morphers sequentially applying them to the
 // element and passes the result as the input of the next morpher unless. If
 // any morpher returns nil, then the element is reused again until all morphers
-// are called.
+// are called. It takes no lock, trees shared between goroutines must apply
+// their morphers within Update.
 func (e *Markup) ApplyMorphers() *Markup {
+	if e.boundary != nil {
+		if e.failure != nil {
+			e.failure = nil
+			e.touch()
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				e.failure = e.boundary.catch(e, r)
+				e.touch()
+			}
+		}()
+
+		atomic.AddInt32(&e.rendering, 1)
+		defer atomic.AddInt32(&e.rendering, -1)
+	} else if closestBoundary(e.parent) != nil {
+		defer annotatePanic(e)
+	}
+
 	for _, child := range e.children {
 		child.ApplyMorphers()
 	}
@@ -366,28 +561,83 @@ func (e *Markup) TextContent() string {
 	return e.textContent
 }
 
+// SetText sets the text content of the markup, replacing any text content
+// function provided.
+func (e *Markup) SetText(txt string) {
+	if e.frozen {
+		return
+	}
+
+	old := e.TextContent()
+
+	e.textContent = txt
+	e.textContentFn = nil
+
+	e.notify(MutationRecord{Type: TextChanged, Target: e, Value: txt, OldValue: old})
+}
+
 // Clean cleans out all internal markup marked as removable.
 func (e *Markup) Clean() {
+	if e.frozen {
+		return
+	}
+
+	defer e.group()()
+	e.clean()
+}
+
+// clean cleans out the removed markup of the markup and its descendants.
+func (e *Markup) clean() {
+	children := e.children[:0]
+
+	var removed []*Markup
+	var indexes []int
+
 	for n, elm := range e.children {
 		if elm.Removed() {
-			copy(e.children[n:], e.children[n+1:])
-			e.children = e.children[:len(e.children)-1]
-		} else {
-			elm.Clean()
+			elm.unsubscribeEvents(true)
+			removed = append(removed, elm)
+			indexes = append(indexes, n)
+			continue
 		}
+
+		elm.clean()
+		children = append(children, elm)
+	}
+
+	// clear out the references left behind in the underline array.
+	for n := len(children); n < len(e.children); n++ {
+		e.children[n] = nil
+	}
+
+	e.children = children
+
+	// deliver removals from the last so each index stays valid when replayed.
+	for n := len(removed) - 1; n >= 0; n-- {
+		e.notify(MutationRecord{Type: ChildRemoved, Target: e, Child: removed[n], Index: indexes[n]})
 	}
 }
 
 // Remove sets the markup as removable and adds a 'NodeRemoved' attribute to it.
 func (e *Markup) Remove() {
+	if e.frozen {
+		return
+	}
+
 	if !e.Removed() {
 		e.attrs = append(e.attrs, &Attribute{Name: "NodeRemoved", Value: ""})
 		e.removed = true
+
+		e.notify(MutationRecord{Type: NodeRemoved, Target: e})
 	}
 }
 
 // UnRemove sets the markup as not to be removable.
 func (e *Markup) UnRemove() {
+	if e.frozen {
+		return
+	}
+
 	if !e.Removed() {
 		return
 	}
@@ -400,8 +650,10 @@ func (e *Markup) UnRemove() {
 		}
 
 		e.attrs = append(e.attrs[:index], e.attrs[1+index:]...)
-		return
+		break
 	}
+
+	e.notify(MutationRecord{Type: NodeUnRemoved, Target: e})
 }
 
 // Removed returns true/false if the Element is marked removed
@@ -411,16 +663,46 @@ func (e *Markup) Removed() bool {
 
 // SwapUID swaps the uid of the internal Element.
 func (e *Markup) SwapUID(uid string) {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
 	e.uid = uid
+
+	// a clone has its own uid now, its events can be subscribed.
+	shared := e.shared
+	e.shared = false
+
+	for index := range e.events {
+		if e.events[index].stale() || (shared && e.events[index].Remove == nil) {
+			ev := e.events[index]
+			ev.resubscribe()
+			e.events[index] = ev
+		}
+	}
 }
 
 // SwapHash swaps the hash of the internal Element.
 func (e *Markup) SwapHash(hash string) {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
 	e.hash = hash
 }
 
 // UpdateHash updates the Element hash value
 func (e *Markup) UpdateHash() {
+	if e.frozen {
+		return
+	}
+
+	e.touch()
+
 	e.hash = RandString(10)
 }
 
@@ -447,9 +729,15 @@ func (e *Markup) Reconcile(em *Markup) bool {
 		return false
 	}
 
+	// both were converted from the same version of a node and not changed.
+	if e.source != nil && e.source == em.source {
+		return false
+	}
+
 	// are we reconciling the proper elements type ? if not skip (i.e different types cant reconcile eachother)]
 	// TODO: decide if we should mark the markup as removed in this case as a catchall system
 	if e.Name() != em.Name() {
+		em.unsubscribeEvents(true)
 		return false
 	}
 
@@ -459,6 +747,11 @@ func (e *Markup) Reconcile(em *Markup) bool {
 	// olduid := em.UID()
 	e.SwapUID(em.UID())
 
+	// the old markup is replaced, its events must not receive the events of
+	// the new markup with the same uid, which keep their limits.
+	e.adoptLimits(em)
+	em.unsubscribeEvents(false)
+
 	//since the tagname are the same and we have swapped uid, to determine who gets or keeps
 	// its hash we will check the attributes against each other, but also the hash is dependent on the
 	// children also, if the children observered there was a change
@@ -485,7 +778,9 @@ func (e *Markup) Reconcile(em *Markup) bool {
 
 	// if the element had no children too, swap hash.
 	if maxSize == 0 {
+		// the old children are dropped with their subscriptions.
 		if oldMaxSize > 0 {
+			em.unsubscribeEvents(true)
 			return true
 		}
 
@@ -499,14 +794,37 @@ func (e *Markup) Reconcile(em *Markup) bool {
 
 	var childChanged bool
 
+	// keyed children are matched by their keys instead of their positions.
+	keyed := make(map[string]int)
+	for n, nch := range newChildren {
+		if nch.key != "" {
+			keyed[nch.key] = n
+		}
+	}
+
 	for n, och := range oldChildren {
-		if maxSize > n {
+		if och.key != "" {
+			if kn, ok := keyed[och.key]; ok && newChildren[kn].Name() == och.Name() {
+				delete(keyed, och.key)
+
+				if newChildren[kn].Reconcile(och) || kn != n {
+					childChanged = true
+				}
+
+				continue
+			}
+
+			e.dropChild(och)
+			childChanged = true
+			continue
+		}
+
+		if maxSize > n && newChildren[n].key == "" {
 
 			nch := newChildren[n]
 			if nch.Name() != och.Name() {
 
-				och.Remove()
-				e.AddChild(och)
+				e.dropChild(och)
 				childChanged = true
 				continue
 			}
@@ -518,8 +836,12 @@ func (e *Markup) Reconcile(em *Markup) bool {
 			continue
 		}
 
-		och.Remove()
-		e.AddChild(och)
+		e.dropChild(och)
+		childChanged = true
+	}
+
+	// new keyed children which had no match in the old children.
+	if len(keyed) != 0 {
 		childChanged = true
 	}
 
@@ -531,6 +853,15 @@ func (e *Markup) Reconcile(em *Markup) bool {
 	return true
 }
 
+// dropChild adds the old child left out by Reconcile to the children of the
+// markup, marked as removed to be dropped by the next Clean and with the
+// events of its subtree unsubscribed, as its handlers must not fire anymore.
+func (e *Markup) dropChild(och *Markup) {
+	och.Remove()
+	e.AddChild(och)
+	och.unsubscribeEvents(true)
+}
+
 // FirstChild returns the first child in the markup children list.
 func (e *Markup) FirstChild() *Markup {
 	return e.NthChild(0)
@@ -554,17 +885,24 @@ func (e *Markup) NthChild(index int) *Markup {
 
 // AddChild adds a new markup as the children of this element
 func (e *Markup) AddChild(child ...*Markup) {
-	if !e.allowChildren {
+	if !e.allowChildren || e.frozen {
 		return
 	}
 
+	if len(child) > 1 {
+		defer e.group()()
+	}
+
 	for _, ch := range child {
 		if ch == e || ch == nil {
 			continue
 		}
 
 		ch.parent = e
+		ch.subscribeEvents()
 		e.children = append(e.children, ch)
+
+		e.notify(MutationRecord{Type: ChildAdded, Target: e, Child: ch, Index: len(e.children) - 1})
 	}
 }
 
@@ -704,6 +1042,9 @@ type Clonable interface {
 
 // CopyTo transfers all attributes to a giving root from the called base.
 func (e *Markup) CopyTo(co *Markup) {
+	if co.frozen {
+		return
+	}
 
 	//copy over the textContent
 	// TODO: Should we not check if we should swap textcontent?
@@ -747,9 +1088,13 @@ func (e *Markup) CopyTo(co *Markup) {
 	co.morphers = append(co.morphers, e.morphers...)
 }
 
-// Clone makes a new copy of the markup structure
+// Clone makes a new copy of the markup structure. The copy keeps the uid of
+// the markup, so the handlers of its events are only subscribed once it gets
+// its uid through SwapUID (e.g when reconciled), not to be called twice for
+// the same event.
 func (e *Markup) Clone() *Markup {
 	co := NewMarkup(e.Name(), e.AutoClosed())
+	co.shared = true
 
 	//copy over the textContent
 	co.textContent = e.textContent
@@ -757,6 +1102,10 @@ func (e *Markup) Clone() *Markup {
 	co.ID = e.ID
 	co.hash = e.hash
 	co.uid = e.uid
+	co.key = e.key
+	co.context = copyContext(e.context)
+	co.boundary = e.boundary
+	co.dispatcher = e.dispatcher
 
 	//copy over the attribute lockers
 	co.allowChildren = e.allowChildren
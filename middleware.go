@@ -0,0 +1,116 @@
+package trees
+
+import "sync"
+
+// EventHandler defines a function type for event callbacks, called with the
+// event object and the markup of the event.
+type EventHandler func(EventObject, *Markup)
+
+// Middleware defines a function type which wraps a EventHandler, e.g for
+// authorization checks, panic recovery, logging or timing. Middlewares are
+// registered globally with UseMiddleware, per dispatcher with Dispatcher.Use
+// or per event with the EventMiddleware option, and applied around every call
+// of an event's handler in that order, each list in the order registered,
+// the first being the outermost.
+type Middleware func(EventHandler) EventHandler
+
+// middlewares contains the global middlewares.
+var middlewares = struct {
+	sync.RWMutex
+	list []*Middleware
+}{}
+
+// UseMiddleware registers the middlewares for the handlers of all events,
+// returning a function which removes them.
+func UseMiddleware(middleware ...Middleware) func() {
+	var added []*Middleware
+
+	middlewares.Lock()
+	for index := range middleware {
+		if middleware[index] == nil {
+			continue
+		}
+
+		mw := middleware[index]
+		added = append(added, &mw)
+		middlewares.list = append(middlewares.list, &mw)
+	}
+	middlewares.Unlock()
+
+	return func() {
+		middlewares.Lock()
+		defer middlewares.Unlock()
+
+		list := middlewares.list[:0:0]
+
+		for _, item := range middlewares.list {
+			var removed bool
+
+			for _, mw := range added {
+				if mw == item {
+					removed = true
+					break
+				}
+			}
+
+			if !removed {
+				list = append(list, item)
+			}
+		}
+
+		middlewares.list = list
+	}
+}
+
+// EventMiddleware adds the middlewares for the handler of the event, applied
+// within the global and dispatcher middlewares.
+func EventMiddleware(middleware ...Middleware) EventOptions {
+	return func(ev *Event) {
+		for _, mw := range middleware {
+			if mw != nil {
+				ev.middleware = append(ev.middleware, mw)
+			}
+		}
+	}
+}
+
+// Use adds the middlewares for the handlers of the events subscribed with the
+// dispatcher, applied within the global middlewares.
+func (d *Dispatcher) Use(middleware ...Middleware) {
+	d.ml.Lock()
+	defer d.ml.Unlock()
+
+	for _, mw := range middleware {
+		if mw != nil {
+			d.middleware = append(d.middleware, mw)
+		}
+	}
+}
+
+// call calls the handler of the event with the middlewares applied.
+func (e *Event) call(ev EventObject, m *Markup) {
+	var chain []Middleware
+
+	middlewares.RLock()
+	for _, mw := range middlewares.list {
+		chain = append(chain, *mw)
+	}
+	middlewares.RUnlock()
+
+	if d := e.scoped(); d != nil {
+		d.ml.RLock()
+		chain = append(chain, d.middleware...)
+		d.ml.RUnlock()
+	}
+
+	chain = append(chain, e.middleware...)
+
+	handler := e.Handler
+	for index := len(chain) - 1; index >= 0; index-- {
+		handler = chain[index](handler)
+	}
+
+	if handler != nil {
+		handler(ev, m)
+	}
+}
@@ -0,0 +1,80 @@
+package trees_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+)
+
+func TestMiddleware(t *testing.T) {
+	var calls []string
+
+	trace := func(name string) events.Middleware {
+		return func(next events.EventHandler) events.EventHandler {
+			return func(ev trees.EventObject, m *trees.Markup) {
+				calls = append(calls, name)
+				next(ev, m)
+			}
+		}
+	}
+
+	recovered := func(next events.EventHandler) events.EventHandler {
+		return func(ev trees.EventObject, m *trees.Markup) {
+			defer func() {
+				if r := recover(); r != nil {
+					calls = append(calls, "recovered")
+				}
+			}()
+
+			next(ev, m)
+		}
+	}
+
+	remove := trees.UseMiddleware(trace("global"))
+
+	dispatcher := trees.NewDispatcher()
+	dispatcher.Use(trace("dispatcher"))
+
+	root := trees.NewMarkup("div", false)
+	dispatcher.Apply(root)
+	defer root.Empty()
+
+	button := trees.NewMarkup("button", false)
+	button.Apply(root)
+
+	click := events.ClickEvent(func() {
+		calls = append(calls, "handler")
+	}, trees.EventMiddleware(trace("event-1"), trace("event-2")))
+	click.Apply(button)
+
+	dispatcher.Dispatch(trees.EventBroadcast{EventID: click.ID(), Event: &trees.MouseEvent{}})
+
+	if got := strings.Join(calls, ","); got != "global,dispatcher,event-1,event-2,handler" {
+		t.Fatalf("\t%s\t  Should have applied middlewares in order: %s", failed, got)
+	}
+	t.Logf("\t%s\t  Should have applied middlewares in order", success)
+
+	calls = nil
+	button.DispatchEvent("click", &trees.MouseEvent{})
+
+	if got := strings.Join(calls, ","); got != "global,dispatcher,event-1,event-2,handler" {
+		t.Fatalf("\t%s\t  Should have applied middlewares to propagated events: %s", failed, got)
+	}
+	t.Logf("\t%s\t  Should have applied middlewares to propagated events", success)
+
+	remove()
+
+	events.KeyDownEvent(func() {
+		panic("failed")
+	}, trees.EventMiddleware(recovered)).Apply(button)
+
+	calls = nil
+	button.DispatchEvent("keydown", &trees.KeyboardEvent{})
+
+	if got := strings.Join(calls, ","); got != "dispatcher,recovered" {
+		t.Fatalf("\t%s\t  Should have removed global middleware and recovered panic: %s", failed, got)
+	}
+	t.Logf("\t%s\t  Should have removed global middleware and recovered panic", success)
+}
@@ -0,0 +1,453 @@
+package trees
+
+import "strings"
+
+// Node defines a immutable representation of a markup, where every change
+// returns a new Node which shares all unchanged children with the Node it was
+// made from. This allows keeping many versions of the same tree (e.g undo
+// stacks or time-travel debugging) with memory growing only by the path
+// changed in each version.
+//
+// The properties, events and morphers of a Node must never be changed
+// directly, they are cloned when converting from and to a *Markup.
+type Node struct {
+	tag       string
+	uid       string
+	hash      string
+	key       string
+	id        string
+	text      string
+	textFn    func(*Markup) string
+	autoclose bool
+	removed   bool
+	allow     [4]bool
+
+	attrs    []Property
+	styles   []Property
+	events   []Event
+	morphers []Morpher
+	children []*Node
+	context  map[interface{}]interface{}
+	boundary *ErrorBoundary
+	dispatch *Dispatcher
+}
+
+const (
+	allowChildren = iota
+	allowAttributes
+	allowStyles
+	allowEvents
+)
+
+// NodeFrom returns a new Node representing the markup and all its descendants.
+func NodeFrom(m *Markup) *Node {
+	n := &Node{
+		tag:       m.tagname,
+		uid:       m.uid,
+		hash:      m.hash,
+		key:       m.key,
+		id:        m.ID,
+		text:      m.textContent,
+		textFn:    m.textContentFn,
+		autoclose: m.autoclose,
+		removed:   m.removed,
+		allow:     [4]bool{m.allowChildren, m.allowAttributes, m.allowStyles, m.allowEvents},
+		context:   copyContext(m.context),
+		boundary:  m.boundary,
+		dispatch:  m.dispatcher,
+	}
+
+	for _, attr := range m.attrs {
+		n.attrs = append(n.attrs, attr.Clone())
+	}
+
+	for _, style := range m.styles {
+		n.styles = append(n.styles, style.Clone())
+	}
+
+	for _, ev := range m.events {
+		n.events = append(n.events, *ev.Clone())
+	}
+
+	n.morphers = append(n.morphers, m.morphers...)
+
+	for _, child := range m.children {
+		n.children = append(n.children, NodeFrom(child))
+	}
+
+	return n
+}
+
+// ToMarkup returns a new *Markup tree with the same uid, hash, properties and
+// children as the node.
+func (n *Node) ToMarkup() *Markup {
+	m := n.markup()
+
+	for _, child := range n.children {
+		cm := child.ToMarkup()
+		cm.parent = m
+		m.children = append(m.children, cm)
+	}
+
+	return m
+}
+
+// markup returns a new *Markup with the same uid, hash and properties as the
+// node, without its children.
+func (n *Node) markup() *Markup {
+	m := &Markup{
+		ID:              n.id,
+		uid:             n.uid,
+		hash:            n.hash,
+		key:             n.key,
+		tagname:         n.tag,
+		textContent:     n.text,
+		textContentFn:   n.textFn,
+		autoclose:       n.autoclose,
+		removed:         n.removed,
+		allowChildren:   n.allow[allowChildren],
+		allowAttributes: n.allow[allowAttributes],
+		allowStyles:     n.allow[allowStyles],
+		allowEvents:     n.allow[allowEvents],
+		context:         copyContext(n.context),
+		boundary:        n.boundary,
+		dispatcher:      n.dispatch,
+		source:          n,
+	}
+
+	for _, attr := range n.attrs {
+		m.attrs = append(m.attrs, attr.Clone())
+	}
+
+	for _, style := range n.styles {
+		m.styles = append(m.styles, style.Clone())
+	}
+
+	for _, ev := range n.events {
+		cev := ev.Clone()
+		cev.Tree = m
+		m.events = append(m.events, *cev)
+	}
+
+	m.morphers = append(m.morphers, n.morphers...)
+
+	return m
+}
+
+// against returns a new *Markup tree of the node to be reconciled against the
+// markup of the other version, where the children shared at the same position
+// by both versions are left without their own children as Reconcile skips
+// markup converted from the same node.
+func (n *Node) against(other *Node) *Markup {
+	m := n.markup()
+	if n == other {
+		return m
+	}
+
+	for index, child := range n.children {
+		var match *Node
+		if other != nil && index < len(other.children) {
+			match = other.children[index]
+		}
+
+		cm := child.against(match)
+		cm.parent = m
+		m.children = append(m.children, cm)
+	}
+
+	return m
+}
+
+// Apply adds a *Markup created from the node into the children of the giving
+// markup.
+func (n *Node) Apply(m *Markup) {
+	if m == nil {
+		return
+	}
+
+	n.ToMarkup().Apply(m)
+}
+
+// HTML returns the html string representing the node.
+func (n *Node) HTML() string {
+	return n.ToMarkup().HTML()
+}
+
+// Reconcile reconciles a *Markup created from the node against one created
+// from the old node as done by Markup.Reconcile, returning the reconciled
+// markup and true/false if it changed. Nodes which are the same version are
+// never marked as changed, and subtrees shared by both versions are neither
+// converted for the old node nor compared.
+func (n *Node) Reconcile(old *Node) (*Markup, bool) {
+	current := n.ToMarkup()
+	if n == old {
+		return current, false
+	}
+
+	return current, current.Reconcile(old.against(n))
+}
+
+//==============================================================================
+
+// Name returns the tag name of the node.
+func (n *Node) Name() string {
+	return n.tag
+}
+
+// UID returns the uid of the node.
+func (n *Node) UID() string {
+	return n.uid
+}
+
+// Hash returns the hash of the node, which changes for every new version of the
+// node.
+func (n *Node) Hash() string {
+	return n.hash
+}
+
+// TextContent returns the text content of the node.
+func (n *Node) TextContent() string {
+	if n.textFn != nil {
+		return n.markup().TextContent()
+	}
+
+	return n.text
+}
+
+// Removed returns true/false if the node is marked removed.
+func (n *Node) Removed() bool {
+	return n.removed
+}
+
+// Attributes returns the attributes of the node, which must not be changed.
+func (n *Node) Attributes() []Property {
+	return n.attrs
+}
+
+// Styles returns the styles of the node, which must not be changed.
+func (n *Node) Styles() []Property {
+	return n.styles
+}
+
+// Events returns the events of the node, which must not be changed.
+func (n *Node) Events() []Event {
+	return n.events
+}
+
+// Children returns a copy of the list of children of the node.
+func (n *Node) Children() []*Node {
+	return append([]*Node(nil), n.children...)
+}
+
+// Child returns the child at the index, returning nil if out of range.
+func (n *Node) Child(index int) *Node {
+	if index < 0 || index >= len(n.children) {
+		return nil
+	}
+
+	return n.children[index]
+}
+
+// PathTo returns the indexes of the children leading from the node to the
+// descendant with the giving uid, returning false if not found.
+func (n *Node) PathTo(uid string) ([]int, bool) {
+	if n.uid == uid {
+		return []int{}, true
+	}
+
+	for index, child := range n.children {
+		if path, ok := child.PathTo(uid); ok {
+			return append([]int{index}, path...), true
+		}
+	}
+
+	return nil, false
+}
+
+//==============================================================================
+
+// copy returns a shallow copy of the node with a new hash, sharing all its
+// lists with the node.
+func (n *Node) copy() *Node {
+	co := *n
+	co.hash = RandString(10)
+	return &co
+}
+
+// WithAttr returns a new version of the node with the attribute set to the
+// giving value.
+func (n *Node) WithAttr(name string, value string) *Node {
+	name = strings.ToLower(name)
+
+	co := n.copy()
+	co.attrs = replaceProperty(n.attrs, NewAttr(name, value))
+
+	if name == "id" {
+		co.id = value
+	}
+
+	return co
+}
+
+// WithoutAttr returns a new version of the node without the attribute.
+func (n *Node) WithoutAttr(name string) *Node {
+	co := n.copy()
+	co.attrs = dropProperty(n.attrs, strings.ToLower(name))
+
+	if strings.ToLower(name) == "id" {
+		co.id = ""
+	}
+
+	return co
+}
+
+// WithStyle returns a new version of the node with the style set to the
+// giving value.
+func (n *Node) WithStyle(name string, value string) *Node {
+	co := n.copy()
+	co.styles = replaceProperty(n.styles, NewCSSStyle(name, value))
+	return co
+}
+
+// WithoutStyle returns a new version of the node without the style.
+func (n *Node) WithoutStyle(name string) *Node {
+	co := n.copy()
+	co.styles = dropProperty(n.styles, strings.ToLower(name))
+	return co
+}
+
+// WithText returns a new version of the node with the giving text content.
+func (n *Node) WithText(text string) *Node {
+	co := n.copy()
+	co.text = text
+	co.textFn = nil
+	return co
+}
+
+// WithRemoved returns a new version of the node marked as removed or not.
+func (n *Node) WithRemoved(state bool) *Node {
+	if n.removed == state {
+		return n
+	}
+
+	co := n.copy()
+	co.removed = state
+
+	if state {
+		co.attrs = append(append([]Property(nil), n.attrs...), &Attribute{Name: "NodeRemoved", Value: ""})
+	} else {
+		co.attrs = dropProperty(n.attrs, "NodeRemoved")
+	}
+
+	return co
+}
+
+// AppendChild returns a new version of the node with the child added at the
+// end of its children.
+func (n *Node) AppendChild(child *Node) *Node {
+	return n.InsertChild(len(n.children), child)
+}
+
+// InsertChild returns a new version of the node with the child inserted at the
+// index of its children. An index out of range returns the node unchanged.
+func (n *Node) InsertChild(index int, child *Node) *Node {
+	if index < 0 || index > len(n.children) {
+		return n
+	}
+
+	co := n.copy()
+	co.children = make([]*Node, 0, len(n.children)+1)
+	co.children = append(co.children, n.children[:index]...)
+	co.children = append(co.children, child)
+	co.children = append(co.children, n.children[index:]...)
+	return co
+}
+
+// ReplaceChild returns a new version of the node with the child at the index
+// replaced.
+func (n *Node) ReplaceChild(index int, child *Node) *Node {
+	if index < 0 || index >= len(n.children) {
+		return n
+	}
+
+	co := n.copy()
+	co.children = append([]*Node(nil), n.children...)
+	co.children[index] = child
+	return co
+}
+
+// RemoveChild returns a new version of the node without the child at the
+// index.
+func (n *Node) RemoveChild(index int) *Node {
+	if index < 0 || index >= len(n.children) {
+		return n
+	}
+
+	co := n.copy()
+	co.children = make([]*Node, 0, len(n.children)-1)
+	co.children = append(co.children, n.children[:index]...)
+	co.children = append(co.children, n.children[index+1:]...)
+	return co
+}
+
+// UpdateAt returns a new version of the node where the descendant found by
+// following the path of children indexes is replaced by the result of the
+// function. Only the nodes along the path are copied, every other child is
+// shared with the node. An invalid path returns the node unchanged.
+func (n *Node) UpdateAt(path []int, fn func(*Node) *Node) *Node {
+	if len(path) == 0 {
+		return fn(n)
+	}
+
+	child := n.Child(path[0])
+	if child == nil {
+		return n
+	}
+
+	updated := child.UpdateAt(path[1:], fn)
+	if updated == child {
+		return n
+	}
+
+	return n.ReplaceChild(path[0], updated)
+}
+
+// Update returns a new version of the node where the descendant with the giving
+// uid is replaced by the result of the function.
+func (n *Node) Update(uid string, fn func(*Node) *Node) *Node {
+	path, ok := n.PathTo(uid)
+	if !ok {
+		return n
+	}
+
+	return n.UpdateAt(path, fn)
+}
+
+// replaceProperty returns a new list with the first property with the same
+// name replaced by the giving one or added if not found.
+func replaceProperty(props []Property, prop Property) []Property {
+	name, _ := prop.Render()
+
+	updated := append([]Property(nil), props...)
+	for index, item := range updated {
+		if itemName, _ := item.Render(); itemName == name {
+			updated[index] = prop
+			return updated
+		}
+	}
+
+	return append(updated, prop)
+}
+
+// dropProperty returns a new list without the properties with the giving name.
+func dropProperty(props []Property, name string) []Property {
+	var updated []Property
+
+	for _, item := range props {
+		if itemName, _ := item.Render(); itemName != name {
+			updated = append(updated, item)
+		}
+	}
+
+	return updated
+}
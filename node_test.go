@@ -0,0 +1,104 @@
+package trees_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+func TestPersistentNode(t *testing.T) {
+	tree := trees.ParseAsRoot("div#page", `
+    <section id="header"><h1>Title</h1></section>
+    <section id="content"><p>Body</p></section>
+  `)
+
+	first := trees.NodeFrom(tree)
+	if first.UID() != tree.UID() || first.HTML() != tree.HTML() {
+		t.Fatalf("\t%s\t  Should have created node matching markup", failed)
+	}
+	t.Logf("\t%s\t  Should have created node matching markup", success)
+
+	title := first.Child(0).Child(0)
+	second := first.Update(title.UID(), func(n *trees.Node) *trees.Node {
+		return n.ReplaceChild(0, n.Child(0).WithText("New Title")).WithAttr("class", "heading")
+	})
+
+	if title.Child(0).TextContent() != "Title" || second.Child(0).Child(0).Child(0).TextContent() != "New Title" {
+		t.Fatalf("\t%s\t  Should have left previous version unchanged", failed)
+	}
+
+	if second.Child(1) != first.Child(1) {
+		t.Fatalf("\t%s\t  Should have shared unchanged children between versions", failed)
+	}
+
+	if second.Child(0) == first.Child(0) || second.Hash() == first.Hash() || second.UID() != first.UID() {
+		t.Fatalf("\t%s\t  Should have copied nodes along the changed path", failed)
+	}
+
+	if !strings.Contains(second.HTML(), `class="heading"`) {
+		t.Fatalf("\t%s\t  Should have rendered changed attribute: %s", failed, second.HTML())
+	}
+	t.Logf("\t%s\t  Should have shared unchanged children between versions", success)
+
+	third := second.UpdateAt([]int{1}, func(n *trees.Node) *trees.Node {
+		return n.RemoveChild(0).AppendChild(trees.NodeFrom(trees.NewMarkup("span", false)))
+	})
+
+	current, changed := third.Reconcile(second)
+	if !changed || current.UID() != third.UID() {
+		t.Fatalf("\t%s\t  Should have reconciled changed version", failed)
+	}
+
+	if _, changed := third.Reconcile(third); changed {
+		t.Fatalf("\t%s\t  Should have not reconciled same version as changed", failed)
+	}
+	t.Logf("\t%s\t  Should have reconciled versions of node", success)
+
+	markup := third.ToMarkup()
+	trees.ReplaceAttribute(markup, "id", "other")
+
+	if third.HTML() == markup.HTML() || len(third.Child(1).Children()) != 1 {
+		t.Fatalf("\t%s\t  Should have isolated node from converted markup", failed)
+	}
+	t.Logf("\t%s\t  Should have isolated node from converted markup", success)
+
+	heading := second.Child(0).Child(0)
+	if attr := heading.WithAttr("DATA-Level", "1").WithoutAttr("data-level"); len(attr.Attributes()) != len(heading.Attributes()) {
+		t.Fatalf("\t%s\t  Should have lowercased attribute names: %d", failed, len(attr.Attributes()))
+	}
+	t.Logf("\t%s\t  Should have lowercased attribute names", success)
+
+	if third.InsertChild(5, trees.NodeFrom(trees.NewMarkup("span", false))) != third {
+		t.Fatalf("\t%s\t  Should have rejected insertion out of range", failed)
+	}
+	t.Logf("\t%s\t  Should have rejected insertion out of range", success)
+}
+
+func TestPersistentNodeSharedReconcile(t *testing.T) {
+	var calls int
+
+	page := trees.NewMarkup("div", false)
+	header := trees.NewMarkup("header", false)
+	trees.NewContextText(func(*trees.Markup) string {
+		calls++
+		return "Title"
+	}).Apply(header)
+	header.Apply(page)
+	trees.NewMarkup("main", false).Apply(page)
+
+	first := trees.NodeFrom(page)
+	second := first.UpdateAt([]int{1}, func(n *trees.Node) *trees.Node {
+		return n.WithAttr("class", "wide")
+	})
+
+	if _, changed := second.Reconcile(first); !changed || calls != 0 {
+		t.Fatalf("\t%s\t  Should have skipped subtrees shared by both versions: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have skipped subtrees shared by both versions", success)
+
+	if second.Child(0).Child(0).TextContent() != "Title" || calls != 1 {
+		t.Fatalf("\t%s\t  Should have computed text content of node: %d", failed, calls)
+	}
+	t.Logf("\t%s\t  Should have computed text content of node", success)
+}
@@ -90,8 +90,15 @@ func (n *Notifications) UnNotify(source EventDistributor) {
 			return
 		}
 
+		delete(n.register, source)
+
 		n.sources[index] = nil
 		n.sources = append(n.sources[:index], n.sources[index+1:]...)
+
+		// shift the index of the sources after the removed one.
+		for _, item := range n.sources[index:] {
+			n.register[item]--
+		}
 	})
 }
 
@@ -105,15 +112,20 @@ func (n *Notifications) Notify(source EventDistributor) {
 
 // Handle will publish giving type to all internal EventDistributor who are
 // expected to convert the needed interface{} into expected type for consumption
-// for their internal state or operations.
+// for their internal state or operations. The distributors are called after
+// releasing the lock, so they can subscribe or unsubscribe distributors.
 func (n *Notifications) Handle(item interface{}) {
+	var sources []EventDistributor
+
 	n.do(func() {
-		for _, source := range n.sources {
-			if source != nil {
-				source.Handle(item)
-			}
-		}
+		sources = append(sources, n.sources...)
 	})
+
+	for _, source := range sources {
+		if source != nil {
+			source.Handle(item)
+		}
+	}
 }
 
 // do performs the needed function call guarded by a mutex call block.
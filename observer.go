@@ -0,0 +1,139 @@
+package trees
+
+// MutationType defines the kind of change described by a MutationRecord.
+type MutationType int
+
+const (
+	// ChildAdded is recorded when a markup is added into the children list
+	// of the target.
+	ChildAdded MutationType = iota + 1
+
+	// ChildRemoved is recorded when a child is taken out of the children list
+	// of the target either through a call to Clean or Empty.
+	ChildRemoved
+
+	// AttributeSet is recorded when a attribute is added to the target or
+	// the value of an existing one was replaced.
+	AttributeSet
+
+	// StyleSet is recorded when a style is added to the target or the value
+	// of an existing one was replaced.
+	StyleSet
+
+	// StyleRemoved is recorded when a style is taken off the target.
+	StyleRemoved
+
+	// TextChanged is recorded when the text content of the target is changed.
+	TextChanged
+
+	// NodeRemoved is recorded when the target is marked as removed.
+	NodeRemoved
+
+	// NodeUnRemoved is recorded when the target is no longer marked as removed.
+	NodeUnRemoved
+)
+
+// String returns the name of the giving mutation type.
+func (m MutationType) String() string {
+	switch m {
+	case ChildAdded:
+		return "ChildAdded"
+	case ChildRemoved:
+		return "ChildRemoved"
+	case AttributeSet:
+		return "AttributeSet"
+	case StyleSet:
+		return "StyleSet"
+	case StyleRemoved:
+		return "StyleRemoved"
+	case TextChanged:
+		return "TextChanged"
+	case NodeRemoved:
+		return "NodeRemoved"
+	case NodeUnRemoved:
+		return "NodeUnRemoved"
+	}
+
+	return "Unknown"
+}
+
+// MutationRecord defines a single change which occured on a markup. Child and
+// Index are set for ChildAdded and ChildRemoved records, where Index is the
+// position of the child within the target's children list. Name, Value and
+// OldValue are set for attribute, style and text changes, where Added is true
+// if the attribute or style did not exists before the change.
+type MutationRecord struct {
+	Type     MutationType
+	Target   *Markup
+	Child    *Markup
+	Index    int
+	Name     string
+	Value    string
+	OldValue string
+	Added    bool
+}
+
+//==============================================================================
+
+// MutationObserver defines a registered callback which receives all
+// MutationRecord occuring on the markup it observes and all of its
+// descendants.
+type MutationObserver struct {
+	target  *Markup
+	handler func(MutationRecord)
+}
+
+// Observe registers the provided function to be called with every
+// MutationRecord which occurs on this markup or any of its descendants when
+// the changes are made through the markup's methods and helpers of this
+// package. It returns the MutationObserver which can be disconnected.
+func (e *Markup) Observe(fn func(MutationRecord)) *MutationObserver {
+	ob := &MutationObserver{target: e, handler: fn}
+	e.observers = append(e.observers, ob)
+	return ob
+}
+
+// Disconnect removes the observer from the markup it observes, it will no longer
+// receive any MutationRecord.
+func (m *MutationObserver) Disconnect() {
+	if m.target == nil {
+		return
+	}
+
+	for index, ob := range m.target.observers {
+		if ob != m {
+			continue
+		}
+
+		m.target.observers = append(m.target.observers[:index], m.target.observers[index+1:]...)
+		break
+	}
+
+	m.target = nil
+}
+
+// notify delivers the giving record to all observers of the markup and its
+// ancestors, dropping the snapshots of the changed markup.
+func (e *Markup) notify(rec MutationRecord) {
+	e.touch()
+
+	for node := e; node != nil; node = node.parent {
+		if len(node.observers) == 0 {
+			continue
+		}
+
+		// copy out the observers, as a handler may disconnect itself.
+		observers := append([]*MutationObserver(nil), node.observers...)
+		for _, ob := range observers {
+			ob.handler(rec)
+		}
+	}
+}
+
+// notifyMutation delivers the giving record if the target is a *Markup.
+func notifyMutation(target interface{}, rec MutationRecord) {
+	if mo, ok := target.(*Markup); ok {
+		rec.Target = mo
+		mo.notify(rec)
+	}
+}
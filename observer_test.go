@@ -0,0 +1,96 @@
+package trees_test
+
+import (
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+func TestMutationObserver(t *testing.T) {
+	root := trees.NewMarkup("div", false)
+	section := trees.NewMarkup("section", false)
+	root.AddChild(section)
+
+	var records []trees.MutationRecord
+	observer := root.Observe(func(rec trees.MutationRecord) {
+		records = append(records, rec)
+	})
+
+	label := trees.NewMarkup("label", false)
+	section.AddChild(label)
+
+	if len(records) != 1 || records[0].Type != trees.ChildAdded || records[0].Child != label {
+		t.Fatalf("\t%s\t  Should have received a ChildAdded record from a descendant: %#v", failed, records)
+	}
+	t.Logf("\t%s\t  Should have received a ChildAdded record from a descendant", success)
+
+	trees.NewAttr("id", "field").Apply(label)
+	trees.NewCSSStyle("width", "100px").Apply(label)
+	trees.ReplaceORAddStyle(label, "width", "200px")
+
+	last := records[len(records)-1]
+	if last.Type != trees.StyleSet || last.Value != "200px" || last.OldValue != "100px" || last.Added {
+		t.Fatalf("\t%s\t  Should have received a StyleSet record with the old value: %#v", failed, last)
+	}
+	t.Logf("\t%s\t  Should have received a StyleSet record with the old value", success)
+
+	label.SetText("name")
+	if last = records[len(records)-1]; last.Type != trees.TextChanged || last.Value != "name" {
+		t.Fatalf("\t%s\t  Should have received a TextChanged record: %#v", failed, last)
+	}
+	t.Logf("\t%s\t  Should have received a TextChanged record", success)
+
+	label.Remove()
+	if last = records[len(records)-1]; last.Type != trees.NodeRemoved || last.Target != label {
+		t.Fatalf("\t%s\t  Should have received a NodeRemoved record: %#v", failed, last)
+	}
+	t.Logf("\t%s\t  Should have received a NodeRemoved record", success)
+
+	root.Clean()
+	if last = records[len(records)-1]; last.Type != trees.ChildRemoved || last.Child != label || last.Target != section {
+		t.Fatalf("\t%s\t  Should have received a ChildRemoved record: %#v", failed, last)
+	}
+	t.Logf("\t%s\t  Should have received a ChildRemoved record", success)
+
+	if len(section.Children()) != 0 {
+		t.Fatalf("\t%s\t  Should have cleaned out removed child", failed)
+	}
+	t.Logf("\t%s\t  Should have cleaned out removed child", success)
+
+	total := len(records)
+	observer.Disconnect()
+	section.AddChild(trees.NewMarkup("span", false))
+
+	if len(records) != total {
+		t.Fatalf("\t%s\t  Should not receive records after disconnecting", failed)
+	}
+	t.Logf("\t%s\t  Should not receive records after disconnecting", success)
+}
+
+func TestMutationObserverEmptyOrder(t *testing.T) {
+	root := trees.NewMarkup("ul", false)
+	for i := 0; i < 4; i++ {
+		root.AddChild(trees.NewMarkup("li", false))
+	}
+
+	replica := append([]*trees.Markup(nil), root.Children()...)
+
+	root.Observe(func(rec trees.MutationRecord) {
+		if rec.Type != trees.ChildRemoved {
+			return
+		}
+
+		if rec.Index >= len(replica) || replica[rec.Index] != rec.Child {
+			t.Fatalf("\t%s\t  Should have removed child at a valid index when replayed: %d", failed, rec.Index)
+		}
+
+		replica = append(replica[:rec.Index], replica[rec.Index+1:]...)
+	})
+
+	root.Empty()
+
+	if len(replica) != 0 {
+		t.Fatalf("\t%s\t  Should have replayed all removals of Empty: %d", failed, len(replica))
+	}
+	t.Logf("\t%s\t  Should have replayed all removals of Empty", success)
+}
@@ -0,0 +1,97 @@
+package trees
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelWriter defines a printer which prints the children of every
+// element on a bounded pool of goroutines, stitching their output in order.
+// Its output is identical to the ElementWriter it uses, including the
+// handling of error boundaries. Children are printed by the calling goroutine
+// whenever the pool is busy, so nested elements never wait on the pool.
+//
+// All textContentFn functions, morphers and stylesheets of a tree printed by
+// a ParallelWriter must be safe for concurrent use.
+type ParallelWriter struct {
+	writer *ElementWriter
+	slots  chan struct{}
+}
+
+// NewParallelWriter returns a new ParallelWriter using the writer with the
+// giving number of workers, using the number of CPUs if workers is less than
+// 1. A nil writer uses the SimpleElementWriter.
+func NewParallelWriter(writer *ElementWriter, workers int) *ParallelWriter {
+	if writer == nil {
+		writer = SimpleElementWriter
+	}
+
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	return &ParallelWriter{
+		writer: writer,
+		slots:  make(chan struct{}, workers),
+	}
+}
+
+// Write prints the giving *Markup as a string else returns an error.
+func (p *ParallelWriter) Write(ma *Markup) (string, error) {
+	return p.Print(ma), nil
+}
+
+// Print returns the string representation of the element.
+func (p *ParallelWriter) Print(e *Markup) string {
+	return p.writer.printNode(e, p.printChildren)
+}
+
+// printChildren prints the children on the pool, panicking with the panic of
+// the first failed child once all children are done.
+func (p *ParallelWriter) printChildren(kids []*Markup) []string {
+	children := make([]string, len(kids))
+	panics := make([]interface{}, len(kids))
+
+	render := func(index int) {
+		defer func() {
+			panics[index] = recover()
+		}()
+
+		children[index] = p.Print(kids[index])
+	}
+
+	var wg sync.WaitGroup
+
+	for index := range kids {
+		// the last child is printed by the calling goroutine, which would
+		// otherwise only wait.
+		if index == len(kids)-1 {
+			render(index)
+			continue
+		}
+
+		select {
+		case p.slots <- struct{}{}:
+			wg.Add(1)
+
+			go func(index int) {
+				defer wg.Done()
+				defer func() { <-p.slots }()
+
+				render(index)
+			}(index)
+		default:
+			render(index)
+		}
+	}
+
+	wg.Wait()
+
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
+
+	return children
+}
@@ -0,0 +1,63 @@
+package trees_test
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gu-io/trees"
+)
+
+func TestParallelWriter(t *testing.T) {
+	var active, peak int32
+
+	page := trees.NewMarkup("div", false)
+	for i := 0; i < 8; i++ {
+		widget := trees.NewMarkup("section", false)
+		trees.NewAttr("id", "widget-"+strconv.Itoa(i)).Apply(widget)
+
+		name := "Widget " + strconv.Itoa(i)
+		widget.AddChild(trees.NewContextText(func(*trees.Markup) string {
+			current := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+
+			for {
+				old := atomic.LoadInt32(&peak)
+				if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			return name
+		}))
+
+		page.AddChild(widget)
+	}
+
+	broken := trees.NewMarkup("aside", false)
+	trees.NewErrorBoundary(func(*trees.RenderError) *trees.Markup {
+		return trees.NewText("fallback")
+	}, nil).Apply(broken)
+	broken.AddChild(trees.NewContextText(func(*trees.Markup) string { panic("broken widget") }))
+	page.AddChild(broken)
+
+	serial := trees.SimpleElementWriter.Print(page)
+	parallel := trees.NewParallelWriter(nil, 4).Print(page)
+
+	if serial != parallel {
+		t.Fatalf("\t%s\t  Should have printed same output as serial writer:\n%s\n%s", failed, serial, parallel)
+	}
+
+	if !strings.Contains(parallel, "fallback") || !strings.Contains(parallel, "Widget 7") {
+		t.Fatalf("\t%s\t  Should have printed widgets and boundary fallback: %s", failed, parallel)
+	}
+	t.Logf("\t%s\t  Should have printed same output as serial writer", success)
+
+	if atomic.LoadInt32(&peak) < 2 {
+		t.Fatalf("\t%s\t  Should have printed children concurrently", failed)
+	}
+	t.Logf("\t%s\t  Should have printed children concurrently", success)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 //==============================================================================
@@ -153,8 +154,60 @@ func (m *ElementWriter) Write(ma *Markup) (string, error) {
 	return m.Print(ma), nil
 }
 
-// Print returns the string representation of the element
+// Print returns the string representation of the element. Panics from the
+// element and its children are caught by the closest error boundary, which
+// prints its fallback instead of its children. Print takes no lock, trees
+// shared between goroutines must be printed within View or Update, or printed
+// from a Snapshot.
 func (m *ElementWriter) Print(e *Markup) string {
+	return m.printNode(e, m.printChildren)
+}
+
+// printNode prints the markup, using the giving function to print its
+// children.
+func (m *ElementWriter) printNode(e *Markup, children func([]*Markup) []string) string {
+	if e.boundary != nil {
+		return m.printBoundary(e, children)
+	}
+
+	if closestBoundary(e.parent) != nil {
+		defer annotatePanic(e)
+	}
+
+	return m.print(e, children)
+}
+
+// printBoundary prints the error boundary markup, printing its fallback if
+// it failed during morphing or printing.
+func (m *ElementWriter) printBoundary(e *Markup, children func([]*Markup) []string) (out string) {
+	if e.failure != nil {
+		return m.printNode(e.boundary.fallback(e, e.failure), children)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			out = m.printNode(e.boundary.fallback(e, e.boundary.catch(e, r)), children)
+		}
+	}()
+
+	atomic.AddInt32(&e.rendering, 1)
+	defer atomic.AddInt32(&e.rendering, -1)
+
+	return m.print(e, children)
+}
+
+// printChildren prints the children in order.
+func (m *ElementWriter) printChildren(kids []*Markup) []string {
+	children := make([]string, 0, len(kids))
+	for _, ch := range kids {
+		children = append(children, m.Print(ch))
+	}
+
+	return children
+}
+
+// print returns the string representation of the element.
+func (m *ElementWriter) print(e *Markup, printChildren func([]*Markup) []string) string {
 	if e.Removed() && GetMode() > Normal {
 		return ""
 	}
@@ -193,15 +246,17 @@ func (m *ElementWriter) Print(e *Markup) string {
 		closer = fmt.Sprintf("</%s>", e.Name())
 	}
 
-	var children = []string{}
+	var kids []*Markup
 	for _, ch := range e.Children() {
 		if ch.UID() == e.UID() {
 			continue
 		}
 
-		children = append(children, m.Print(ch))
+		kids = append(kids, ch)
 	}
 
+	children := printChildren(kids)
+
 	//lets create the elements markup now
 	return strings.Join([]string{
 		fmt.Sprintf("<%s", e.Name()),
@@ -0,0 +1,166 @@
+package trees
+
+import "strings"
+
+// EventPhase defines the phase of the propagation of an event dispatched with
+// DispatchEvent.
+type EventPhase int
+
+// contains the phases of the propagation of an event.
+const (
+	NoPhase EventPhase = iota
+	CapturingPhase
+	AtTargetPhase
+	BubblingPhase
+)
+
+// propagation defines the state of an event being dispatched.
+type propagation struct {
+	phase      EventPhase
+	target     *Markup
+	current    *Markup
+	stopped    bool
+	stoppedNow bool
+}
+
+// StopPropagation stops the event from reaching the markups after the current
+// one once its handlers are called.
+func (b *BaseEvent) StopPropagation() {
+	b.propagation.stopped = true
+}
+
+// StopImmediatePropagation stops the event from reaching any other handler,
+// including those of the current markup.
+func (b *BaseEvent) StopImmediatePropagation() {
+	b.propagation.stopped = true
+	b.propagation.stoppedNow = true
+}
+
+// PreventDefault marks the default action of the event as prevented if the
+// event is cancelable.
+func (b *BaseEvent) PreventDefault() {
+	if b.Cancelable {
+		b.DefaultPrevented = true
+	}
+}
+
+// Phase returns the phase of the event while being dispatched.
+func (b *BaseEvent) Phase() EventPhase {
+	return b.propagation.phase
+}
+
+// TargetMarkup returns the markup the event was dispatched at, if any.
+func (b *BaseEvent) TargetMarkup() *Markup {
+	return b.propagation.target
+}
+
+// CurrentTargetMarkup returns the markup whose handlers are being called
+// while the event is dispatched, if any.
+func (b *BaseEvent) CurrentTargetMarkup() *Markup {
+	return b.propagation.current
+}
+
+// DispatchEvent dispatches the event of the giving type at the markup,
+// calling the handlers of the matching events of its parents with UseCapture
+// from the root down, then those of the markup, then, if the event bubbles,
+// those of its parents without UseCapture up to the root. The Target and
+// CurrentTarget of events embedding BaseEvent are set to the selectors of the
+// markups as the event propagates.
+//
+// The StopPropagation, StopImmediatePropagation and PreventDefault flags of
+// an Event are applied once its handler is called, just as handlers can call
+// the methods of the same name of BaseEvent. Event objects which do not
+// embed BaseEvent always bubble and are cancelable, they can only be stopped
+// or have their default action prevented through the flags. Event types are
+// matched case insensitively. It returns false if the default action of the
+// event was prevented.
+func (e *Markup) DispatchEvent(eventType string, ev EventObject) bool {
+	base := &BaseEvent{Bubbles: true, Cancelable: true}
+	if based, ok := ev.(interface{ base() *BaseEvent }); ok {
+		base = based.base()
+	}
+
+	if base.Type == "" {
+		base.Type = eventType
+	}
+
+	base.Target = e.IDSelector(false)
+	base.propagation = propagation{target: e}
+
+	var parents []*Markup
+	for node := e.parent; node != nil; node = node.parent {
+		parents = append(parents, node)
+	}
+
+	func() {
+		for index := len(parents) - 1; index >= 0; index-- {
+			if !propagate(parents[index], CapturingPhase, eventType, ev, base) {
+				return
+			}
+		}
+
+		if !propagate(e, AtTargetPhase, eventType, ev, base) || !base.Bubbles {
+			return
+		}
+
+		for _, parent := range parents {
+			if !propagate(parent, BubblingPhase, eventType, ev, base) {
+				return
+			}
+		}
+	}()
+
+	base.CurrentTarget = ""
+	base.propagation.phase = NoPhase
+	base.propagation.current = nil
+
+	return !base.DefaultPrevented
+}
+
+// propagate calls the handlers of the markup's events of the giving type for
+// the phase, returning false if the propagation was stopped.
+func propagate(m *Markup, phase EventPhase, eventType string, ev EventObject, base *BaseEvent) bool {
+	base.CurrentTarget = m.IDSelector(false)
+	base.propagation.phase = phase
+	base.propagation.current = m
+
+	listeners := append([]Event(nil), m.events...)
+
+	// at the target, capturing handlers are called before the others.
+	passes := []bool{phase == CapturingPhase}
+	if phase == AtTargetPhase {
+		passes = []bool{true, false}
+	}
+
+	for _, capture := range passes {
+		for _, listener := range listeners {
+			if listener.Handler == nil || !strings.EqualFold(listener.Type, eventType) || listener.UseCapture != capture {
+				continue
+			}
+
+			prevented := base.DefaultPrevented
+			listener.handle(ev, m)
+
+			// passive handlers can not prevent the default action.
+			if listener.Passive {
+				base.DefaultPrevented = prevented
+			} else if listener.PreventDefault {
+				base.PreventDefault()
+			}
+
+			if listener.StopImmediatePropagation {
+				base.StopImmediatePropagation()
+			}
+
+			if listener.StopPropagation {
+				base.StopPropagation()
+			}
+
+			if base.propagation.stoppedNow {
+				return false
+			}
+		}
+	}
+
+	return !base.propagation.stopped
+}
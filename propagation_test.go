@@ -0,0 +1,106 @@
+package trees_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+	"github.com/gu-io/trees/events"
+)
+
+func TestDispatchEvent(t *testing.T) {
+	var calls []string
+
+	record := func(name string) func(*trees.MouseEvent, *trees.Markup) {
+		return func(ev *trees.MouseEvent, current *trees.Markup) {
+			if ev.CurrentTargetMarkup() != current {
+				t.Fatalf("\t%s\t  Should have set current target to markup of handler", failed)
+			}
+
+			calls = append(calls, name)
+		}
+	}
+
+	list := trees.NewMarkup("ul", false)
+	item := trees.NewMarkup("li", false)
+	button := trees.NewMarkup("button", false)
+	item.Apply(list)
+	button.Apply(item)
+
+	defer list.Empty()
+
+	events.ClickEvent(record("ul-capture"), trees.UseCapture(true)).Apply(list)
+	events.ClickEvent(record("ul-bubble")).Apply(list)
+	events.ClickEvent(record("li-bubble")).Apply(item)
+	events.ClickEvent(record("button")).Apply(button)
+	events.ClickEvent(record("button-capture"), trees.UseCapture(true)).Apply(button)
+	events.KeyDownEvent(func(ev *trees.KeyboardEvent) {
+		calls = append(calls, "button-keydown")
+	}).Apply(button)
+
+	click := &trees.MouseEvent{BaseEvent: trees.BaseEvent{Bubbles: true, Cancelable: true}}
+	if !button.DispatchEvent("click", click) {
+		t.Fatalf("\t%s\t  Should have not prevented default action", failed)
+	}
+
+	if got := strings.Join(calls, ","); got != "ul-capture,button-capture,button,li-bubble,ul-bubble" {
+		t.Fatalf("\t%s\t  Should have called handlers in capture then bubble order: %s", failed, got)
+	}
+	t.Logf("\t%s\t  Should have called handlers in capture then bubble order", success)
+
+	if click.TargetMarkup() != button || click.Target != button.IDSelector(false) || click.Phase() != trees.NoPhase {
+		t.Fatalf("\t%s\t  Should have exposed target of dispatched event", failed)
+	}
+	t.Logf("\t%s\t  Should have exposed target of dispatched event", success)
+
+	calls = nil
+	button.DispatchEvent("click", &trees.MouseEvent{})
+
+	if got := strings.Join(calls, ","); got != "ul-capture,button-capture,button" {
+		t.Fatalf("\t%s\t  Should have not bubbled event which does not bubble: %s", failed, got)
+	}
+	t.Logf("\t%s\t  Should have not bubbled event which does not bubble", success)
+
+	events.ClickEvent(func(ev *trees.MouseEvent) {
+		ev.StopPropagation()
+		calls = append(calls, "li-stop")
+	}).Apply(item)
+
+	calls = nil
+	button.DispatchEvent("click", &trees.MouseEvent{BaseEvent: trees.BaseEvent{Bubbles: true}})
+
+	if got := strings.Join(calls, ","); got != "ul-capture,button-capture,button,li-bubble,li-stop" {
+		t.Fatalf("\t%s\t  Should have stopped propagation after current markup: %s", failed, got)
+	}
+	t.Logf("\t%s\t  Should have stopped propagation after current markup", success)
+
+	events.ClickEvent(func() {
+		calls = append(calls, "button-stop")
+	}, trees.StopImmediatePropagation(true), trees.PreventDefault(true), trees.UseCapture(true)).Apply(button)
+
+	calls = nil
+	prevented := &trees.MouseEvent{BaseEvent: trees.BaseEvent{Bubbles: true, Cancelable: true}}
+
+	if button.DispatchEvent("click", prevented) || !prevented.DefaultPrevented {
+		t.Fatalf("\t%s\t  Should have prevented default action through event flag", failed)
+	}
+	t.Logf("\t%s\t  Should have prevented default action through event flag", success)
+
+	if got := strings.Join(calls, ","); got != "ul-capture,button-capture,button-stop" {
+		t.Fatalf("\t%s\t  Should have stopped immediate propagation through event flag: %s", failed, got)
+	}
+	t.Logf("\t%s\t  Should have stopped immediate propagation through event flag", success)
+
+	calls = nil
+	if button.DispatchEvent("click", plainEvent{}) {
+		t.Fatalf("\t%s\t  Should have prevented default action of plain event through event flag", failed)
+	}
+	t.Logf("\t%s\t  Should have prevented default action of plain event through event flag", success)
+}
+
+// plainEvent defines a event object which does not embed BaseEvent.
+type plainEvent struct{}
+
+func (plainEvent) RemoveEvent() {}
+
+func (plainEvent) Underlying() interface{} { return nil }
@@ -79,7 +79,7 @@ func (a *Attribute) Render() (string, string) {
 
 // Apply applies a set change to the giving element attributes list
 func (a *Attribute) Apply(e *Markup) {
-	if e.allowAttributes {
+	if e.allowAttributes && !e.frozen {
 		e.AddAttribute(a)
 
 		if a.After != nil {
@@ -148,7 +148,9 @@ func (c *ClassList) Render() (string, string) {
 
 // Apply checks for a class attribute
 func (c *ClassList) Apply(em *Markup) {
-	if em.allowAttributes {
+	if em.allowAttributes && !em.frozen {
+		defer em.group()()
+
 		var old Property
 		index := -1
 
@@ -168,15 +170,18 @@ func (c *ClassList) Apply(em *Markup) {
 			return
 		}
 
+		_, oldValue := old.Render()
+
 		if cold, ok := old.(*ClassList); ok {
 			cold.Add(c.list...)
 		} else {
-			_, val := old.Render()
 			oldSet := c.list
-			c.list = append([]string{val}, oldSet...)
+			c.list = append([]string{oldValue}, oldSet...)
 			em.attrs[index] = c
 		}
 
+		_, value := em.attrs[index].Render()
+		em.notify(MutationRecord{Type: AttributeSet, Target: em, Name: "class", Value: value, OldValue: oldValue})
 	}
 }
 
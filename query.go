@@ -1,9 +1,20 @@
 package trees
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
 
 // Query defines a package level variable for access the query interface
 // which handles running css queries on markup structures.
+//
+// Beyond standard css selectors, the following extensions are supported:
+// :contains(text) and :matches(regexp) which check the text content of the
+// markup and its descendants, :has(selector) which checks for a descendant
+// matching the selector, :removed which checks if the markup is marked removed,
+// :has-event(type) which checks for a event of the giving type and
+// [style~=name:value] which checks the styles of the markup.
 var Query queryCtrl
 
 type queryCtrl struct{}
@@ -92,29 +103,9 @@ func (q queryCtrl) QueryAll(root *Markup, sel string) []*Markup {
 // QuerySelector uses the provided selector and root returning the first
 // element that matches the selector's criteria.
 func (q queryCtrl) QuerySelector(root *Markup, sel *Selector) *Markup {
-	var filtered *Markup
-
-childloop:
-	for _, child := range root.children {
-		if q.queryOne(child, sel) {
-			filtered = child
-			break childloop
-		}
-
-		for _, kid := range child.children {
-			if q.queryOne(kid, sel) {
-				filtered = kid
-				break childloop
-			}
+	filtered := q.queryFirst(root, sel)
 
-			if item := q.QuerySelector(kid, sel); item != nil {
-				filtered = item
-				break childloop
-			}
-		}
-	}
-
-	if sel.Children == nil {
+	if sel.Children == nil || filtered == nil {
 		return filtered
 	}
 
@@ -131,6 +122,12 @@ childloop:
 // QueryAllSelector uses the provided selector and root returning all
 // elements that matches the selector's criteria.
 func (q queryCtrl) QueryAllSelector(root *Markup, sel *Selector) []*Markup {
+	if sel.Children == nil {
+		if found, ok := q.queryIndexed(root, sel); ok {
+			return found
+		}
+	}
+
 	var found []*Markup
 
 	for _, child := range root.children {
@@ -183,6 +180,59 @@ func (q queryCtrl) QueryAllSelector(root *Markup, sel *Selector) []*Markup {
 	return found
 }
 
+// queryFirst returns the first descendant of the root matching the selector.
+func (q queryCtrl) queryFirst(root *Markup, sel *Selector) *Markup {
+	if found, ok := q.queryIndexed(root, sel); ok {
+		if len(found) == 0 {
+			return nil
+		}
+
+		return found[0]
+	}
+
+	for _, child := range root.children {
+		if q.queryOne(child, sel) {
+			return child
+		}
+
+		for _, kid := range child.children {
+			if q.queryOne(kid, sel) {
+				return kid
+			}
+
+			if item := q.QuerySelector(kid, sel); item != nil {
+				return item
+			}
+		}
+	}
+
+	return nil
+}
+
+// queryIndexed returns all descendants of the root matching the selector in
+// document order using the index covering the root, it returns false if the
+// root is not indexed or the index can not answer for the selector.
+func (q queryCtrl) queryIndexed(root *Markup, sel *Selector) ([]*Markup, bool) {
+	idx := root.indexed
+	if idx == nil {
+		return nil, false
+	}
+
+	candidates, ok := idx.candidates(sel)
+	if !ok {
+		return nil, false
+	}
+
+	var found []*Markup
+	for _, item := range idx.within(root, candidates) {
+		if q.queryOne(item, sel) {
+			found = append(found, item)
+		}
+	}
+
+	return found, true
+}
+
 func (q queryCtrl) queryOne(target *Markup, sel *Selector) bool {
 	if sel.Tag != "" && !q.tagFor(target, sel.Tag) {
 		return false
@@ -192,6 +242,10 @@ func (q queryCtrl) queryOne(target *Markup, sel *Selector) bool {
 		return false
 	}
 
+	if sel.Psuedo != "" && !q.pseudoFor(target, sel.Psuedo) {
+		return false
+	}
+
 	if sel.Classes != nil {
 		for _, class := range sel.Classes {
 			if !q.classFor(target, class) {
@@ -216,8 +270,11 @@ var (
 	space       = byte(' ')
 	bracket     = byte('[')
 	endbracket  = byte(']')
+	colon       = byte(':')
 	orderOpen   = byte('(')
 	orderClosed = byte(')')
+	quote       = byte('\'')
+	doubleQuote = byte('"')
 )
 
 // ParseSelector returns the giving selector parsed out into its individual
@@ -412,6 +469,20 @@ func (q queryCtrl) ParseSelector(sel string) []*Selector {
 
 							blk = nil
 							continue parseLoop
+						case colon:
+							pseudo, end := q.readPseudo(items, index)
+
+							if doChildren {
+								child.Classes = append(child.Classes, string(blk))
+								child.Psuedo += pseudo
+							} else {
+								csel.Classes = append(csel.Classes, string(blk))
+								csel.Psuedo += pseudo
+							}
+
+							blk = nil
+							index = end
+							continue parseLoop
 						case orderOpen:
 							var order []byte
 							order = append(order, item)
@@ -620,6 +691,21 @@ func (q queryCtrl) ParseSelector(sel string) []*Selector {
 							blk = nil
 							continue parseLoop
 
+						case colon:
+							pseudo, end := q.readPseudo(items, index)
+
+							if doChildren {
+								child.ID = string(blk)
+								child.Psuedo += pseudo
+							} else {
+								csel.ID = string(blk)
+								csel.Psuedo += pseudo
+							}
+
+							blk = nil
+							index = end
+							continue parseLoop
+
 						}
 
 						blk = append(blk, item)
@@ -715,6 +801,15 @@ func (q queryCtrl) ParseSelector(sel string) []*Selector {
 
 						item := items[index]
 
+						// arguments of psuedo selectors are read as a whole,
+						// e.g :contains(some text), :has(a.active).
+						if item == orderOpen && q.inPseudo(blk) {
+							group, end := q.readGroup(items, index)
+							blk = append(blk, group...)
+							index = end - 1
+							continue defaultLoop
+						}
+
 						switch item {
 						case space, coma, hash, dot, bracket, endbracket:
 							if doChildren {
@@ -852,6 +947,68 @@ func (q queryCtrl) ParseSelector(sel string) []*Selector {
 	return sels
 }
 
+// inPseudo returns true/false if the block ends with a psuedo selector
+// which has no arguments yet.
+func (queryCtrl) inPseudo(blk []byte) bool {
+	index := strings.LastIndexByte(string(blk), colon)
+	if index == -1 {
+		return false
+	}
+
+	return strings.IndexByte(string(blk[index:]), orderOpen) == -1
+}
+
+// readGroup reads the parenthesis group starting at the index, including any
+// nested groups and quoted text, returning the group and the index after it.
+func (queryCtrl) readGroup(items []byte, index int) ([]byte, int) {
+	var depth int
+	var quoted byte
+
+	for end := index; end < len(items); end++ {
+		item := items[end]
+
+		switch {
+		case quoted != 0:
+			if item == quoted {
+				quoted = 0
+			}
+		case item == quote || item == doubleQuote:
+			quoted = item
+		case item == orderOpen:
+			depth++
+		case item == orderClosed:
+			depth--
+			if depth == 0 {
+				return items[index : end+1], end + 1
+			}
+		}
+	}
+
+	return items[index:], len(items)
+}
+
+// readPseudo reads the psuedo selectors starting at the index, returning them
+// and the index after them.
+func (q queryCtrl) readPseudo(items []byte, index int) (string, int) {
+	var pseudo []byte
+
+	for index < len(items) {
+		switch item := items[index]; item {
+		case space, coma, dot, hash, bracket:
+			return string(pseudo), index
+		case orderOpen:
+			group, end := q.readGroup(items, index)
+			pseudo = append(pseudo, group...)
+			index = end
+		default:
+			pseudo = append(pseudo, item)
+			index++
+		}
+	}
+
+	return string(pseudo), index
+}
+
 var (
 	exactMatch           = "="
 	exactWordInListMatch = "~="
@@ -920,13 +1077,36 @@ func (queryCtrl) splitBracketSelector(sel string) (string, string, string) {
 	return sel, "", ""
 }
 
-func (queryCtrl) attrFor(target *Markup, attrName string, attrVal string, op string) bool {
-	attr, err := GetAttr(target, attrName)
-	if err != nil {
-		return false
-	}
+func (q queryCtrl) attrFor(target *Markup, attrName string, attrVal string, op string) bool {
+	var val string
 
-	_, val := attr.Render()
+	if attrName == "style" {
+		styles := q.stylesFor(target)
+		if len(styles) == 0 {
+			return false
+		}
+
+		// match each style declaration as a word, e.g [style~=display:none].
+		if op == exactWordInListMatch {
+			attrVal = strings.Replace(attrVal, " ", "", -1)
+			for _, style := range styles {
+				if style == attrVal {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		val = strings.Join(styles, ";")
+	} else {
+		attr, err := GetAttr(target, attrName)
+		if err != nil {
+			return false
+		}
+
+		_, val = attr.Render()
+	}
 
 	switch op {
 	case exactMatch:
@@ -969,6 +1149,8 @@ func (queryCtrl) tagFor(target *Markup, tag string) bool {
 	return target.tagname == tag
 }
 
+// classFor returns true/false if the class list of the target contains the
+// class as one of its names.
 func (queryCtrl) classFor(target *Markup, class string) bool {
 	attr, err := GetAttr(target, "class")
 	if err != nil {
@@ -976,7 +1158,13 @@ func (queryCtrl) classFor(target *Markup, class string) bool {
 	}
 
 	_, val := attr.Render()
-	return strings.Contains(val, class)
+	for _, name := range strings.Fields(val) {
+		if name == class {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (queryCtrl) idFor(target *Markup, id string) bool {
@@ -991,3 +1179,157 @@ func (queryCtrl) idFor(target *Markup, id string) bool {
 
 	return false
 }
+
+// stylesFor returns the styles of the target as a list of 'name:value'
+// declarations from both its style properties and any style attribute.
+func (queryCtrl) stylesFor(target *Markup) []string {
+	var styles []string
+
+	if attr, err := GetAttr(target, "style"); err == nil {
+		_, value := attr.Render()
+		for _, style := range strings.Split(value, ";") {
+			if style = strings.Replace(style, " ", "", -1); style != "" {
+				styles = append(styles, style)
+			}
+		}
+	}
+
+	for _, style := range target.Styles() {
+		name, value := style.Render()
+		styles = append(styles, strings.TrimSpace(name)+":"+strings.Replace(value, " ", "", -1))
+	}
+
+	return styles
+}
+
+// textFor returns the text content of the target and all its descendants
+// excluding comments.
+func (q queryCtrl) textFor(target *Markup) string {
+	content := target.TextContent()
+	if target.tagname == "text" && strings.HasPrefix(content, "<!--") {
+		return ""
+	}
+
+	for _, child := range target.children {
+		content += q.textFor(child)
+	}
+
+	return content
+}
+
+// pseudoFor returns true/false if the target matches all psuedo selectors
+// provided. Psuedo selectors which are not supported always match.
+func (q queryCtrl) pseudoFor(target *Markup, pseudo string) bool {
+	items := []byte(pseudo)
+
+	for index := 0; index < len(items); {
+		if items[index] == colon {
+			index++
+			continue
+		}
+
+		var name []byte
+		for index < len(items) && items[index] != colon && items[index] != orderOpen {
+			name = append(name, items[index])
+			index++
+		}
+
+		var arg string
+		if index < len(items) && items[index] == orderOpen {
+			group, end := q.readGroup(items, index)
+			arg = q.unquote(strings.TrimSuffix(strings.TrimPrefix(string(group), "("), ")"))
+			index = end
+		}
+
+		if !q.pseudoMatch(target, string(name), arg) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (q queryCtrl) pseudoMatch(target *Markup, name string, arg string) bool {
+	switch name {
+	case "contains":
+		return strings.Contains(q.textFor(target), arg)
+
+	case "matches":
+		matcher, err := pseudoRegexp(arg)
+		if err != nil {
+			return false
+		}
+
+		return matcher.MatchString(q.textFor(target))
+
+	case "has":
+		return q.Query(target, arg) != nil
+
+	case "removed":
+		return target.Removed()
+
+	case "has-event":
+		for _, ev := range target.Events() {
+			if strings.EqualFold(ev.Type, arg) || strings.EqualFold(ev.EventName(), arg) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// unquote removes the quotes surrounding the value if any.
+func (queryCtrl) unquote(value string) string {
+	value = strings.TrimSpace(value)
+
+	if len(value) > 1 {
+		first, last := value[0], value[len(value)-1]
+		if first == last && (first == quote || first == doubleQuote) {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
+
+// maxPseudoRegexps defines the maximum number of expressions kept by
+// pseudoRegexps.
+const maxPseudoRegexps = 128
+
+// pseudoRegexps caches the expressions used by the :matches psuedo selector,
+// up to maxPseudoRegexps of them as the selectors may come from users.
+var pseudoRegexps = struct {
+	ml    sync.Mutex
+	items map[string]*regexp.Regexp
+}{
+	items: make(map[string]*regexp.Regexp),
+}
+
+// pseudoRegexp returns the compiled expression, from the cache if kept.
+func pseudoRegexp(expr string) (*regexp.Regexp, error) {
+	pseudoRegexps.ml.Lock()
+	defer pseudoRegexps.ml.Unlock()
+
+	if matcher, ok := pseudoRegexps.items[expr]; ok {
+		return matcher, nil
+	}
+
+	matcher, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	// drops an arbitrary expression to make room for the new one.
+	if len(pseudoRegexps.items) >= maxPseudoRegexps {
+		for key := range pseudoRegexps.items {
+			delete(pseudoRegexps.items, key)
+			break
+		}
+	}
+
+	pseudoRegexps.items[expr] = matcher
+	return matcher, nil
+}
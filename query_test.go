@@ -156,4 +156,70 @@ func TestQueries(t *testing.T) {
 	}
 	tests.Passed("Should have returned 3 elements for selector 'section.section'")
 
+	// class selectors match whole class names as in css, not parts of them.
+	if items := trees.Query.QueryAll(tree, ".wrap"); len(items) != 0 {
+		tests.Failed("Should have matched no element for partial class name '.wrap': %d", len(items))
+	}
+	tests.Passed("Should have matched no element for partial class name '.wrap'")
+
+	defer trees.NewIndex(tree).Detach()
+	if items := trees.Query.QueryAll(tree, ".wrap"); len(items) != 0 {
+		tests.Failed("Should have matched no indexed element for partial class name '.wrap': %d", len(items))
+	}
+	tests.Passed("Should have matched no indexed element for partial class name '.wrap'")
+
+	if items := trees.Query.QueryAll(tree, "div.wrapper"); len(items) != 1 {
+		tests.Failed("Should have matched indexed element for class name 'wrapper': %d", len(items))
+	}
+	tests.Passed("Should have matched indexed element for class name 'wrapper'")
+}
+
+func TestExtendedSelectors(t *testing.T) {
+	tree := trees.ParseAsRoot("section#house", `
+    <ul class="menu">
+      <li class="item"><a href="#home">Home page</a></li>
+      <li class="item"><a href="#about">About us, and more</a></li>
+      <li class="item">Contact</li>
+    </ul>
+  `)
+
+	hidden := trees.Query.Query(tree, "li:contains(Contact)")
+	if hidden == nil {
+		tests.Failed("Should have returned li containing text 'Contact'")
+	}
+	tests.Passed("Should have returned li containing text 'Contact'")
+
+	trees.NewCSSStyle("display", "none").Apply(hidden)
+	trees.NewEvent(trees.EventType("click")).Apply(hidden)
+
+	if item := trees.Query.Query(tree, `a:contains("About us, and more")`); item == nil {
+		tests.Failed("Should have returned anchor with quoted text")
+	}
+	tests.Passed("Should have returned anchor with quoted text")
+
+	if items := trees.Query.QueryAll(tree, ".item:has(a[href=#home])"); len(items) != 1 {
+		tests.Failed("Should have returned 1 item with a home link: %d", len(items))
+	}
+	tests.Passed("Should have returned 1 item with a home link")
+
+	if items := trees.Query.QueryAll(tree, `li:matches(^(Home|About))`); len(items) != 2 {
+		tests.Failed("Should have returned 2 items matching expression: %d", len(items))
+	}
+	tests.Passed("Should have returned 2 items matching expression")
+
+	if item := trees.Query.Query(tree, "li[style~=display:none]"); item != hidden {
+		tests.Failed("Should have returned hidden item using style selector")
+	}
+	tests.Passed("Should have returned hidden item using style selector")
+
+	if item := trees.Query.Query(tree, ".item:has-event(click)"); item != hidden {
+		tests.Failed("Should have returned item with click event")
+	}
+	tests.Passed("Should have returned item with click event")
+
+	hidden.Remove()
+	if items := trees.Query.QueryAll(tree, "li:removed"); len(items) != 1 || items[0] != hidden {
+		tests.Failed("Should have returned removed item")
+	}
+	tests.Passed("Should have returned removed item")
 }
@@ -0,0 +1,251 @@
+package trees
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Patch defines the combined changes of a single flush of a Scheduler. It
+// contains the reconciled renders of all changed components and the marked
+// subtrees, where changes within another change of the patch are left out
+// as they are part of it.
+type Patch struct {
+	Changes []*Markup
+}
+
+// Empty returns true/false if the patch has no changes.
+func (p Patch) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// PatchJSON returns the MarkupJSON for all changes of the patch.
+func (p Patch) PatchJSON() []MarkupJSON {
+	var pjson []MarkupJSON
+
+	for _, change := range p.Changes {
+		pjson = append(pjson, change.TreeJSON())
+	}
+
+	return pjson
+}
+
+// Scheduler defines a render scheduler which batches updates of components
+// and subtrees marked dirty, coalescing all marks made before a flush into a
+// single re-render of each component and a single Patch.
+//
+// Components are re-rendered in parent-before-child order, a component which
+// was already re-rendered by its parent within the flush is not rendered
+// again. Scheduler is safe for concurrent use for marking, while flushes
+// should be made from a single goroutine, either by calling Flush or by a
+// ticker started with Start. Flushes of a shared tree must hold its lock,
+// which is done by setting a runner with UseRunner.
+type Scheduler struct {
+	handler func(Patch)
+	runner  func(flush func())
+
+	mu     sync.Mutex
+	mounts map[*Mount]struct{}
+	trees  map[*Markup]struct{}
+
+	flush  sync.Mutex
+	ticker *time.Ticker
+	stop   chan struct{}
+}
+
+// NewScheduler returns a new Scheduler which calls the giving handler with
+// every non-empty Patch produced by a flush. The handler can be nil.
+func NewScheduler(handler func(Patch)) *Scheduler {
+	return &Scheduler{
+		handler: handler,
+		mounts:  make(map[*Mount]struct{}),
+		trees:   make(map[*Markup]struct{}),
+	}
+}
+
+// Mark marks the component of the mount as dirty, to be re-rendered on the
+// next flush.
+func (s *Scheduler) Mark(m *Mount) {
+	s.mu.Lock()
+	s.mounts[m] = struct{}{}
+	s.mu.Unlock()
+}
+
+// MarkTree marks the subtree as changed, to be added to the next patch.
+func (s *Scheduler) MarkTree(m *Markup) {
+	s.mu.Lock()
+	s.trees[m] = struct{}{}
+	s.mu.Unlock()
+}
+
+// UseRunner sets the function making the flushes of the scheduler, which
+// must call the function while holding the lock guarding the tree (e.g
+// within Markup.Update of its root). The handler of the scheduler is called
+// within the flush, so it sees the tree as reconciled by it. Flushes must
+// then not be made while holding the lock.
+func (s *Scheduler) UseRunner(runner func(flush func())) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runner = runner
+}
+
+// Dirty returns true/false if there are marks waiting for a flush.
+func (s *Scheduler) Dirty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.mounts) != 0 || len(s.trees) != 0
+}
+
+// Flush re-renders all dirty components through the runner of the scheduler
+// if any, returning the combined Patch of all changes made since the last
+// flush.
+func (s *Scheduler) Flush() Patch {
+	s.flush.Lock()
+	defer s.flush.Unlock()
+
+	s.mu.Lock()
+	runner := s.runner
+	s.mu.Unlock()
+
+	if runner == nil {
+		return s.render()
+	}
+
+	var patch Patch
+
+	runner(func() {
+		patch = s.render()
+	})
+
+	return patch
+}
+
+// render re-renders all dirty components and calls the handler with the
+// combined Patch of all changes, returning it.
+func (s *Scheduler) render() Patch {
+	s.mu.Lock()
+	mounts, trees := s.mounts, s.trees
+	s.mounts = make(map[*Mount]struct{})
+	s.trees = make(map[*Markup]struct{})
+	s.mu.Unlock()
+
+	dirty := make([]*Mount, 0, len(mounts))
+	renders := make(map[*Mount]int, len(mounts))
+
+	for m := range mounts {
+		if !m.mounted {
+			continue
+		}
+
+		dirty = append(dirty, m)
+		renders[m] = m.renders
+	}
+
+	sort.SliceStable(dirty, func(i, j int) bool {
+		return depthOf(dirty[i].current) < depthOf(dirty[j].current)
+	})
+
+	var changes []*Markup
+
+	for _, m := range dirty {
+		// re-rendered within the render of a parent component.
+		if m.renders != renders[m] {
+			continue
+		}
+
+		if m.Update() {
+			changes = append(changes, m.current)
+		}
+	}
+
+	for tree := range trees {
+		changes = append(changes, tree)
+	}
+
+	var patch Patch
+
+	for _, change := range changes {
+		var covered bool
+
+		for _, other := range changes {
+			if other != change && isDescendant(other, change) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			patch.Changes = append(patch.Changes, change)
+		}
+	}
+
+	sort.SliceStable(patch.Changes, func(i, j int) bool {
+		return depthOf(patch.Changes[i]) < depthOf(patch.Changes[j])
+	})
+
+	if s.handler != nil && !patch.Empty() {
+		s.handler(patch)
+	}
+
+	return patch
+}
+
+// Start starts a ticker flushing the scheduler at every interval where there
+// are marks waiting for a flush.
+func (s *Scheduler) Start(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ticker != nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	stop := make(chan struct{})
+
+	s.ticker = ticker
+	s.stop = stop
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if s.Dirty() {
+					s.Flush()
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the ticker started by Start.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ticker == nil {
+		return
+	}
+
+	s.ticker.Stop()
+	close(s.stop)
+
+	s.ticker = nil
+	s.stop = nil
+}
+
+// depthOf returns the number of ancestors of the markup.
+func depthOf(m *Markup) int {
+	var depth int
+
+	for m.parent != nil {
+		depth++
+		m = m.parent
+	}
+
+	return depth
+}
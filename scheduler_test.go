@@ -0,0 +1,93 @@
+package trees_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+type label struct {
+	text    string
+	renders int
+}
+
+func (l *label) Render() *trees.Markup {
+	l.renders++
+	return trees.ParseTree(`<span>` + l.text + `</span>`)[0]
+}
+
+type panel struct {
+	title   string
+	inner   *trees.Mount
+	renders int
+}
+
+func (p *panel) Render() *trees.Markup {
+	p.renders++
+
+	root := trees.ParseTree(`<section><h1>` + p.title + `</h1></section>`)[0]
+	p.inner.Apply(root)
+	return root
+}
+
+func TestSchedulerFlush(t *testing.T) {
+	inner := &label{text: "one"}
+	outer := &panel{title: "first", inner: trees.NewMount(inner)}
+
+	page := trees.NewMarkup("div", false)
+	outerMount := trees.NewMount(outer)
+	outerMount.Apply(page)
+
+	var patches []trees.Patch
+	scheduler := trees.NewScheduler(func(p trees.Patch) {
+		patches = append(patches, p)
+	})
+
+	for i := 0; i < 5; i++ {
+		inner.text = "count-" + strconv.Itoa(i)
+		scheduler.Mark(outer.inner)
+	}
+
+	patch := scheduler.Flush()
+	if inner.renders != 2 || len(patch.Changes) != 1 || patch.Changes[0] != outer.inner.Markup() {
+		t.Fatalf("\t%s\t  Should have coalesced marks into a single render: %d", failed, inner.renders)
+	}
+	t.Logf("\t%s\t  Should have coalesced marks into a single render", success)
+
+	outer.title = "second"
+	inner.text = "two"
+	scheduler.Mark(outer.inner)
+	scheduler.Mark(outerMount)
+
+	patch = scheduler.Flush()
+	if outer.renders != 2 || inner.renders != 3 {
+		t.Fatalf("\t%s\t  Should have rendered parent before child once: %d %d", failed, outer.renders, inner.renders)
+	}
+
+	if len(patch.Changes) != 1 || patch.Changes[0] != outerMount.Markup() {
+		t.Fatalf("\t%s\t  Should have combined changes into parent render", failed)
+	}
+	t.Logf("\t%s\t  Should have rendered parent before child once", success)
+
+	if !scheduler.Flush().Empty() || len(patches) != 2 {
+		t.Fatalf("\t%s\t  Should have emitted only non-empty patches: %d", failed, len(patches))
+	}
+	t.Logf("\t%s\t  Should have emitted only non-empty patches", success)
+
+	var flushes int
+	scheduler.UseRunner(func(flush func()) {
+		page.Update(func(*trees.Markup) {
+			flushes++
+			flush()
+		})
+	})
+
+	inner.text = "three"
+	scheduler.Mark(outer.inner)
+
+	if patch := scheduler.Flush(); flushes != 1 || len(patch.Changes) != 1 || len(patches) != 3 {
+		t.Fatalf("\t%s\t  Should have flushed through runner: %d", failed, flushes)
+	}
+	t.Logf("\t%s\t  Should have flushed through runner", success)
+}
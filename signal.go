@@ -0,0 +1,264 @@
+package trees
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Signal defines an observable value which can be bound to the text,
+// attributes, styles and class entries of markup. Setting the value of a
+// signal updates only the bound markup, which emits the usual mutation
+// records and gets a new hash, without any render or reconciliation of the
+// surrounding tree.
+//
+// The value of a signal is safe for concurrent use but the bound markup are
+// changed by the goroutine calling Set, which must follow the rules of the
+// tree (e.g call Set within Markup.Update).
+//
+// Like the handlers of events, the bindings of a markup are released when it
+// is cleaned, emptied out of its parent or replaced through Reconcile, and
+// restored when it is added back to a tree.
+type Signal struct {
+	mu          sync.Mutex
+	value       interface{}
+	bindings    []*signalBinding
+	subscribers []*signalSubscriber
+}
+
+// signalBinding defines a markup bound to a signal and the function applying
+// the rendered value of the signal to it. The owner is the markup the binding
+// was applied to, which is the parent of the target for Text.
+type signalBinding struct {
+	signal *Signal
+	owner  *Markup
+	target *Markup
+	apply  func(m *Markup, old string, value string)
+}
+
+// signalSubscriber defines a function subscribed to a signal.
+type signalSubscriber struct {
+	fn func(interface{})
+}
+
+// NewSignal returns a new Signal with the initial value.
+func NewSignal(initial interface{}) *Signal {
+	return &Signal{value: initial}
+}
+
+// Get returns the current value of the signal.
+func (s *Signal) Get() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.value
+}
+
+// String returns the current value of the signal as rendered into markup.
+func (s *Signal) String() string {
+	return fmt.Sprint(s.Get())
+}
+
+// Set sets the value of the signal, updating all bound markup and calling all
+// subscribers if the rendered value changed.
+func (s *Signal) Set(value interface{}) {
+	s.mu.Lock()
+	old := fmt.Sprint(s.value)
+	s.value = value
+	bindings := append([]*signalBinding(nil), s.bindings...)
+	subscribers := append([]*signalSubscriber(nil), s.subscribers...)
+	s.mu.Unlock()
+
+	rendered := fmt.Sprint(value)
+	if rendered == old {
+		return
+	}
+
+	for _, binding := range bindings {
+		binding.apply(binding.target, old, rendered)
+		binding.target.UpdateHash()
+	}
+
+	for _, sub := range subscribers {
+		sub.fn(value)
+	}
+}
+
+// Subscribe adds a function called with every changed value of the signal,
+// returning a function which removes it.
+func (s *Signal) Subscribe(fn func(interface{})) func() {
+	sub := &signalSubscriber{fn: fn}
+
+	s.mu.Lock()
+	s.subscribers = append(s.subscribers, sub)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for index, item := range s.subscribers {
+			if item == sub {
+				s.subscribers = append(s.subscribers[:index], s.subscribers[index+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Unbind removes all bindings of the signal applied to the markup, including
+// the text markup added by Text.
+func (s *Signal) Unbind(m *Markup) {
+	s.mu.Lock()
+	var removed []*signalBinding
+	var bindings []*signalBinding
+	for _, binding := range s.bindings {
+		if binding.owner == m || binding.target == m {
+			removed = append(removed, binding)
+			continue
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	s.bindings = bindings
+	s.mu.Unlock()
+
+	for _, binding := range removed {
+		binding.target.dropBinding(binding)
+	}
+}
+
+// Text returns a Appliable which adds a text markup showing the value of
+// the signal.
+func (s *Signal) Text() Appliable {
+	return signalApplier(func(m *Markup) {
+		text := NewText("%s", s.String())
+		m.AddChild(text)
+
+		s.bind(m, text, func(t *Markup, _ string, value string) {
+			t.SetText(value)
+		})
+	})
+}
+
+// Attr returns a Appliable which binds the attribute of the markup to the
+// value of the signal.
+func (s *Signal) Attr(name string) Appliable {
+	return signalApplier(func(m *Markup) {
+		ReplaceORAddAttribute(m, name, s.String())
+
+		s.bind(m, m, func(t *Markup, _ string, value string) {
+			ReplaceORAddAttribute(t, name, value)
+		})
+	})
+}
+
+// Style returns a Appliable which binds the style of the markup to the value
+// of the signal.
+func (s *Signal) Style(name string) Appliable {
+	return signalApplier(func(m *Markup) {
+		ReplaceORAddStyle(m, name, s.String())
+
+		s.bind(m, m, func(t *Markup, _ string, value string) {
+			ReplaceORAddStyle(t, name, value)
+		})
+	})
+}
+
+// Class returns a Appliable which binds a single entry of the class list of
+// the markup to the value of the signal, leaving all other classes as is.
+func (s *Signal) Class() Appliable {
+	return signalApplier(func(m *Markup) {
+		swapClass(m, "", s.String())
+		s.bind(m, m, swapClass)
+	})
+}
+
+// bind adds the binding of the target markup applied to the owner markup to
+// the signal, keeping it on the target to be released and restored with it.
+func (s *Signal) bind(owner *Markup, target *Markup, apply func(*Markup, string, string)) {
+	if target.frozen {
+		return
+	}
+
+	binding := &signalBinding{signal: s, owner: owner, target: target, apply: apply}
+	target.bindings = append(target.bindings, binding)
+	s.attach(binding)
+}
+
+// attach adds the binding to the signal, unless it is already added.
+func (s *Signal) attach(binding *signalBinding) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range s.bindings {
+		if item == binding {
+			return
+		}
+	}
+
+	s.bindings = append(s.bindings, binding)
+}
+
+// detach removes the binding from the signal.
+func (s *Signal) detach(binding *signalBinding) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for index, item := range s.bindings {
+		if item == binding {
+			s.bindings = append(s.bindings[:index], s.bindings[index+1:]...)
+			return
+		}
+	}
+}
+
+// dropBinding removes the binding from the bindings of the markup.
+func (e *Markup) dropBinding(binding *signalBinding) {
+	for index, item := range e.bindings {
+		if item == binding {
+			e.bindings = append(e.bindings[:index], e.bindings[index+1:]...)
+			return
+		}
+	}
+}
+
+// signalApplier defines a function type which implements Appliable.
+type signalApplier func(*Markup)
+
+// Apply calls the function with the markup.
+func (fn signalApplier) Apply(m *Markup) {
+	fn(m)
+}
+
+// swapClass replaces the old class name of the markup with the new one.
+func swapClass(m *Markup, old string, value string) {
+	var current string
+	if attr, err := GetAttr(m, "class"); err == nil {
+		_, current = attr.Render()
+	}
+
+	var classes []string
+	var swapped bool
+
+	for _, class := range strings.Fields(current) {
+		if class == old && !swapped {
+			swapped = true
+
+			if value != "" {
+				classes = append(classes, value)
+			}
+
+			continue
+		}
+
+		classes = append(classes, class)
+	}
+
+	if !swapped && value != "" {
+		classes = append(classes, value)
+	}
+
+	m.setProperty(false, "class", strings.Join(classes, " "))
+}
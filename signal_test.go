@@ -0,0 +1,110 @@
+package trees_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+func TestSignalBindings(t *testing.T) {
+	count := trees.NewSignal(1)
+	status := trees.NewSignal("badge-ok")
+
+	card := trees.ParseTree(`<div class="card"><h1>Title</h1></div>`)[0]
+	badge := trees.NewMarkup("span", false)
+
+	count.Text().Apply(badge)
+	count.Attr("data-count").Apply(badge)
+	count.Style("order").Apply(badge)
+	status.Class().Apply(badge)
+	card.AddChild(badge)
+
+	if html := badge.HTML(); !strings.Contains(html, `data-count="1"`) || !strings.Contains(html, "order:1") || !strings.Contains(html, `class="badge-ok"`) {
+		t.Fatalf("\t%s\t  Should have applied signal values: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have applied signal values", success)
+
+	var records []trees.MutationRecord
+	card.Observe(func(rec trees.MutationRecord) {
+		records = append(records, rec)
+	})
+
+	cardHash, badgeHash := card.Hash(), badge.Hash()
+
+	count.Set(42)
+	status.Set("badge-error")
+
+	html := badge.HTML()
+	if !strings.Contains(html, ">42<") || !strings.Contains(html, `data-count="42"`) || !strings.Contains(html, "order:42") || !strings.Contains(html, `class="badge-error"`) {
+		t.Fatalf("\t%s\t  Should have updated bound markup: %s", failed, html)
+	}
+
+	if card.Hash() != cardHash || badge.Hash() == badgeHash || len(records) != 4 {
+		t.Fatalf("\t%s\t  Should have changed only bound markup: %d records", failed, len(records))
+	}
+	t.Logf("\t%s\t  Should have updated only bound markup", success)
+
+	var seen interface{}
+	unsubscribe := count.Subscribe(func(v interface{}) { seen = v })
+	count.Set(7)
+	unsubscribe()
+	count.Set(8)
+
+	if seen != 7 {
+		t.Fatalf("\t%s\t  Should have notified subscribers until removed: %v", failed, seen)
+	}
+
+	count.Unbind(badge)
+	count.Set(9)
+	if html := badge.HTML(); strings.Contains(html, `data-count="9"`) || strings.Contains(html, ">9<") {
+		t.Fatalf("\t%s\t  Should have unbound markup and its text: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have notified subscribers and unbound markup", success)
+}
+
+func TestSignalBindingsLifetime(t *testing.T) {
+	count := trees.NewSignal(1)
+
+	list := trees.NewMarkup("ul", false)
+	item := trees.NewMarkup("li", false)
+	count.Text().Apply(item)
+	count.Attr("data-count").Apply(item)
+	list.AddChild(item)
+
+	item.Remove()
+	list.Clean()
+
+	count.Set(2)
+	if html := item.HTML(); strings.Contains(html, ">2<") || strings.Contains(html, `data-count="2"`) {
+		t.Fatalf("\t%s\t  Should have released bindings of cleaned markup: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have released bindings of cleaned markup", success)
+
+	list.AddChild(item)
+
+	count.Set(3)
+	if html := item.HTML(); !strings.Contains(html, ">3<") || !strings.Contains(html, `data-count="3"`) {
+		t.Fatalf("\t%s\t  Should have restored bindings of added markup: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have restored bindings of added markup", success)
+
+	list.Empty()
+
+	count.Set(4)
+	if html := item.HTML(); strings.Contains(html, ">4<") || strings.Contains(html, `data-count="4"`) {
+		t.Fatalf("\t%s\t  Should have released bindings of emptied markup: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have released bindings of emptied markup", success)
+
+	old := trees.NewMarkup("span", false)
+	count.Attr("data-count").Apply(old)
+
+	trees.NewMarkup("span", false).Reconcile(old)
+
+	count.Set(5)
+	if html := old.HTML(); strings.Contains(html, `data-count="5"`) {
+		t.Fatalf("\t%s\t  Should have released bindings of replaced markup: %s", failed, html)
+	}
+	t.Logf("\t%s\t  Should have released bindings of replaced markup", success)
+}
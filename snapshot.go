@@ -0,0 +1,158 @@
+package trees
+
+// Root returns the top most ancestor of the markup, returning itself if it
+// has no parent.
+func (e *Markup) Root() *Markup {
+	root := e
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	return root
+}
+
+// Update calls the giving function with the markup while holding the write
+// lock of the markup's root, blocking all calls to View, Update and Snapshot
+// on the same tree until it returns.
+func (e *Markup) Update(fn func(*Markup)) {
+	root := e.Root()
+
+	root.rw.Lock()
+	defer root.rw.Unlock()
+
+	fn(e)
+}
+
+// View calls the giving function with the markup while holding the read
+// lock of the markup's root. The function must not change the tree.
+func (e *Markup) View(fn func(*Markup)) {
+	root := e.Root()
+
+	root.rw.RLock()
+	defer root.rw.RUnlock()
+
+	fn(e)
+}
+
+// Snapshot returns a frozen copy of the markup and its descendants made while
+// holding the read lock of the markup's root. The copy keeps the uid, hash
+// and removed state of the original markup so it can be printed or
+// reconciled against, but all changes to it through its methods and the
+// helpers of this package are ignored.
+//
+// Frozen copies are kept by the markup until it changes, so an unchanged tree
+// returns the same snapshot. Unchanged markup within a changed tree is copied
+// again with the parent of the new snapshot, sharing the properties of its
+// last copy, so each snapshot resolves its context, selectors and boundaries
+// through its own ancestors. Changes must be made through the markup methods
+// and the helpers of this package, as changing the fields of the markup or
+// of its properties directly (e.g Markup.ID, Attribute.Value) is not noticed
+// by the kept copies.
+func (e *Markup) Snapshot() *Markup {
+	if e.frozen {
+		return e
+	}
+
+	var snap *Markup
+
+	e.View(func(m *Markup) {
+		root := m.Root()
+
+		// concurrent snapshots share the read lock but not the kept copies.
+		root.snap.Lock()
+		defer root.snap.Unlock()
+
+		snap = m.freeze(nil)
+	})
+
+	return snap
+}
+
+// Frozen returns true/false if the markup is a frozen copy created by Snapshot.
+func (e *Markup) Frozen() bool {
+	return e.frozen
+}
+
+// touch drops the frozen copies kept for the markup and its ancestors, which
+// must be copied again by the next snapshot, and the node versions they were
+// converted from.
+func (e *Markup) touch() {
+	for node := e; node != nil; node = node.parent {
+		node.snapshot = nil
+		node.source = nil
+	}
+}
+
+// freeze returns a frozen copy of the markup attached to the giving parent,
+// reusing the copy kept from the last snapshot if the markup did not change
+// and it has the same parent, else sharing its properties if unchanged.
+func (e *Markup) freeze(parent *Markup) *Markup {
+	kept := e.snapshot
+	if kept != nil && kept.parent == parent {
+		return kept
+	}
+
+	co := &Markup{
+		ID:              e.ID,
+		removed:         e.removed,
+		autoclose:       e.autoclose,
+		allowEvents:     e.allowEvents,
+		allowChildren:   e.allowChildren,
+		allowStyles:     e.allowStyles,
+		allowAttributes: e.allowAttributes,
+		uid:             e.uid,
+		hash:            e.hash,
+		key:             e.key,
+		tagname:         e.tagname,
+		textContent:     e.textContent,
+		idSelector:      e.idSelector,
+		textContentFn:   e.textContentFn,
+		parent:          parent,
+		context:         copyContext(e.context),
+		boundary:        e.boundary,
+		failure:         e.failure,
+		async:           e.async,
+		frozen:          true,
+	}
+
+	// frozen properties are never changed, the copies can share them.
+	if kept != nil {
+		co.attrs, co.styles = kept.attrs, kept.styles
+	} else {
+		co.attrs = make([]Property, 0, len(e.attrs))
+		for _, attr := range e.attrs {
+			co.attrs = append(co.attrs, attr.Clone())
+		}
+
+		co.styles = make([]Property, 0, len(e.styles))
+		for _, style := range e.styles {
+			co.styles = append(co.styles, style.Clone())
+		}
+	}
+
+	co.events = make([]Event, 0, len(e.events))
+	for _, ev := range e.events {
+		cev := ev.Clone()
+		cev.Tree = co
+		co.events = append(co.events, *cev)
+	}
+
+	co.children = make([]*Markup, 0, len(e.children))
+	for _, child := range e.children {
+		co.children = append(co.children, child.freeze(co))
+	}
+
+	co.morphers = append(co.morphers, e.morphers...)
+
+	e.snapshot = co
+	return co
+}
+
+// isFrozen returns true/false if the target is a frozen *Markup.
+func isFrozen(target interface{}) bool {
+	if mo, ok := target.(*Markup); ok {
+		return mo.frozen
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package trees_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+func TestSnapshot(t *testing.T) {
+	root := generateMarkup()
+	remover := &trees.RemoveMorpher{}
+
+	label := trees.Query.Query(root, "label")
+	label.AddMorpher(remover)
+
+	snap := root.Snapshot()
+	if !snap.Frozen() || snap.UID() != root.UID() || snap.HTML() != root.HTML() {
+		t.Fatalf("\t%s\t  Should have created frozen snapshot matching the live tree", failed)
+	}
+	t.Logf("\t%s\t  Should have created frozen snapshot matching the live tree", success)
+
+	snap.AddChild(trees.NewMarkup("span", false))
+	trees.ReplaceORAddStyle(snap, "width", "10px")
+
+	if len(snap.Children()) != len(root.Children()) || snap.HTML() != root.HTML() {
+		t.Fatalf("\t%s\t  Should have ignored changes to frozen snapshot", failed)
+	}
+	t.Logf("\t%s\t  Should have ignored changes to frozen snapshot", success)
+
+	first := root.Snapshot()
+	if first != root.Snapshot() {
+		t.Fatalf("\t%s\t  Should have reused snapshot of unchanged tree", failed)
+	}
+	t.Logf("\t%s\t  Should have reused snapshot of unchanged tree", success)
+
+	trees.ReplaceORAddAttribute(label, "for", "name")
+
+	second := root.Snapshot()
+	if second == first || second.HTML() != root.HTML() || second.HTML() == first.HTML() {
+		t.Fatalf("\t%s\t  Should have copied changed markup into new snapshot", failed)
+	}
+	t.Logf("\t%s\t  Should have copied changed markup into new snapshot", success)
+
+	// the first div is unchanged while the second one holds the label.
+	unchanged, changed := second.Children()[0], second.Children()[1]
+	if unchanged.Parent() != second || first.Children()[0].Parent() != first || unchanged.Attributes()[0] != first.Children()[0].Attributes()[0] || changed.Children()[0].Attributes()[0] == first.Children()[1].Children()[0].Attributes()[0] {
+		t.Fatalf("\t%s\t  Should have shared only properties of unchanged markup between snapshots", failed)
+	}
+	t.Logf("\t%s\t  Should have shared only properties of unchanged markup between snapshots", success)
+
+	section := trees.Query.Query(root, "section")
+	section.Parent().Provide("theme", "a")
+
+	before := root.Snapshot()
+	section.Parent().Provide("theme", "b")
+	after := root.Snapshot()
+
+	if trees.Query.Query(before, "section").ContextValue("theme") != "a" || trees.Query.Query(after, "section").ContextValue("theme") != "b" {
+		t.Fatalf("\t%s\t  Should have resolved context through ancestors of each snapshot", failed)
+	}
+	t.Logf("\t%s\t  Should have resolved context through ancestors of each snapshot", success)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 50; i++ {
+			if i%2 == 0 {
+				remover.On(nil)
+			} else {
+				remover.Off(nil)
+			}
+
+			root.Update(func(m *trees.Markup) {
+				m.ApplyMorphers()
+				trees.ReplaceORAddStyle(m, "width", "auto")
+			})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 50; i++ {
+			root.Snapshot().HTML()
+		}
+	}()
+
+	wg.Wait()
+	t.Logf("\t%s\t  Should have rendered snapshots while the tree changed", success)
+}
@@ -239,6 +239,10 @@ func AttrContains(e Attributes, f, val string) bool {
 
 // GetAttr returns the attribute with the specified tag name
 func GetAttr(e Attributes, f string) (Property, error) {
+	if mo, ok := e.(*Markup); ok && mo.indexed != nil {
+		return mo.indexed.Attr(mo, f)
+	}
+
 	for _, as := range e.Attributes() {
 		name, _ := as.Render()
 		if name == f {
@@ -251,6 +255,10 @@ func GetAttr(e Attributes, f string) (Property, error) {
 // ReplaceStyle replaces a specific style with the given
 // name with the supplied value.
 func ReplaceStyle(m Styles, name string, val string) {
+	if isFrozen(m) {
+		return
+	}
+
 	styl, err := GetStyle(m, name)
 	if err != nil {
 		return
@@ -261,12 +269,19 @@ func ReplaceStyle(m Styles, name string, val string) {
 		return
 	}
 
+	old := stylm.Value
 	stylm.Value = val
+
+	notifyMutation(m, MutationRecord{Type: StyleSet, Name: name, Value: val, OldValue: old})
 }
 
 // ReplaceAttribute replaces a specific attribute with the given
 // name with the supplied value.
 func ReplaceAttribute(m Attributes, name string, val string) {
+	if isFrozen(m) {
+		return
+	}
+
 	attr, err := GetAttr(m, name)
 	if err != nil {
 		return
@@ -277,13 +292,20 @@ func ReplaceAttribute(m Attributes, name string, val string) {
 		return
 	}
 
+	old := attrm.Value
 	attrm.Value = val
+
+	notifyMutation(m, MutationRecord{Type: AttributeSet, Name: name, Value: val, OldValue: old})
 }
 
 // ReplaceORAddStyle replaces a specific style with the given
 // name with the supplied value if not found it adds a new one
 // if found and if the type does not match a *CSSStyle then it stops.
 func ReplaceORAddStyle(m Properties, name string, val string) {
+	if isFrozen(m) {
+		return
+	}
+
 	styl, err := GetStyle(m, name)
 	if err != nil {
 		m.AddStyle(NewCSSStyle(name, val))
@@ -295,13 +317,20 @@ func ReplaceORAddStyle(m Properties, name string, val string) {
 		return
 	}
 
+	old := stylm.Value
 	stylm.Value = val
+
+	notifyMutation(m, MutationRecord{Type: StyleSet, Name: name, Value: val, OldValue: old})
 }
 
 // ReplaceORAddAttribute replaces a specific attribute with the given
 // name with the supplied value if not found it adds a new one
 // if found and if the type does not match a *CSSStyle then it stops.
 func ReplaceORAddAttribute(m Properties, name string, val string) {
+	if isFrozen(m) {
+		return
+	}
+
 	attr, err := GetAttr(m, name)
 	if err != nil {
 		m.AddAttribute(NewAttr(name, val))
@@ -309,13 +338,20 @@ func ReplaceORAddAttribute(m Properties, name string, val string) {
 	}
 
 	if attrm, ok := attr.(*Attribute); ok {
+		old := attrm.Value
 		attrm.Value = val
+
+		notifyMutation(m, MutationRecord{Type: AttributeSet, Name: name, Value: val, OldValue: old})
 		return
 	}
 
 	if classlist, ok := attr.(*ClassList); ok {
+		_, old := classlist.Render()
+
 		classlist.list = nil
 		classlist.list = append(classlist.list, val)
+
+		notifyMutation(m, MutationRecord{Type: AttributeSet, Name: name, Value: val, OldValue: old})
 	}
 }
 
@@ -342,6 +378,23 @@ func ElementsUsingStyle(root *Markup, key string, val string) []*Markup {
 // stlye restrictions passed.
 // NOTE: is uses AttrContains
 func ElementsWithAttr(root *Markup, key string, val string) []*Markup {
+	if idx := root.indexed; idx != nil {
+		candidates := make(markupSet)
+		for name, items := range idx.attrs {
+			if !strings.Contains(name, key) {
+				continue
+			}
+
+			for item := range items {
+				if AttrContains(item, key, val) {
+					candidates[item] = struct{}{}
+				}
+			}
+		}
+
+		return idx.within(root, candidates)
+	}
+
 	var found []*Markup
 
 	for _, ch := range root.Children() {
@@ -361,6 +414,10 @@ func ElementsWithTag(root *Markup, tag string) []*Markup {
 	var found []*Markup
 
 	tag = strings.TrimSpace(strings.ToLower(tag))
+	if idx := root.indexed; idx != nil {
+		return idx.within(root, idx.tags[tag])
+	}
+
 	for _, ch := range root.Children() {
 		if ch.Name() == tag {
 			found = append(found, ch)
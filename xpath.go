@@ -0,0 +1,1702 @@
+package trees
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// XPath defines a package level variable for access the xpath interface
+// which handles evaluating XPath 1.0 expressions on markup structures.
+//
+// The evaluator treats text markup as text nodes (or comment nodes when
+// their content is wrapped in '<!--' and '-->'), every other markup as element
+// nodes and the top most ancestor of the markup evaluated against as the
+// document element. Namespaces, processing instructions and variables are
+// not supported.
+var XPath xpathCtrl
+
+type xpathCtrl struct{}
+
+// XPathExpr defines a compiled XPath expression which can be evaluated
+// against different markup.
+type XPathExpr struct {
+	source string
+	root   xpExpr
+}
+
+// Compile parses the giving expression returning the XPathExpr for it else
+// returning an error if the expression is invalid.
+func (xpathCtrl) Compile(expr string) (*XPathExpr, error) {
+	tokens, err := lexXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &xpParser{source: expr, tokens: tokens}
+
+	root, err := parser.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &XPathExpr{source: expr, root: root}, nil
+}
+
+// Select returns all markup matched by the giving expression using the root
+// as the context node.
+func (x xpathCtrl) Select(root *Markup, expr string) ([]*Markup, error) {
+	compiled, err := x.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return compiled.Select(root)
+}
+
+// SelectOne returns the first markup in document order matched by the giving
+// expression, it returns nil if nothing matched.
+func (x xpathCtrl) SelectOne(root *Markup, expr string) (*Markup, error) {
+	found, err := x.Select(root, expr)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+
+	return found[0], nil
+}
+
+// Evaluate returns the result of the giving expression using the root as the
+// context node. The result is either a float64, string, bool, []*Markup
+// for node-sets or []Property for node-sets made up of attributes only.
+func (x xpathCtrl) Evaluate(root *Markup, expr string) (interface{}, error) {
+	compiled, err := x.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return compiled.Evaluate(root)
+}
+
+// String returns the source of the expression.
+func (x *XPathExpr) String() string {
+	return x.source
+}
+
+// Select returns all markup matched by the expression using the root as the
+// context node. It returns ErrNotNodeSet if the expression does not result in a
+// node-set.
+func (x *XPathExpr) Select(root *Markup) ([]*Markup, error) {
+	value, err := x.eval(root)
+	if err != nil {
+		return nil, err
+	}
+
+	set, ok := value.(xpNodeSet)
+	if !ok {
+		return nil, ErrNotNodeSet
+	}
+
+	return set.markup(), nil
+}
+
+// Evaluate returns the result of the expression using the root as the context
+// node. See xpathCtrl.Evaluate for the possible result types.
+func (x *XPathExpr) Evaluate(root *Markup) (interface{}, error) {
+	value, err := x.eval(root)
+	if err != nil {
+		return nil, err
+	}
+
+	set, ok := value.(xpNodeSet)
+	if !ok {
+		return value, nil
+	}
+
+	if props := set.attributes(); props != nil {
+		return props, nil
+	}
+
+	return set.markup(), nil
+}
+
+// eval runs the expression recovering any evaluation error raised.
+func (x *XPathExpr) eval(root *Markup) (value xpValue, err error) {
+	if root == nil {
+		return nil, ErrNotMarkup
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			xerr, ok := rec.(xpError)
+			if !ok {
+				panic(rec)
+			}
+
+			err = xerr
+		}
+	}()
+
+	top := root
+	for top.parent != nil {
+		top = top.parent
+	}
+
+	doc := &xpDocument{top: top}
+	value = x.root.eval(&xpContext{doc: doc, node: doc.nodeFor(root), position: 1, size: 1})
+	return value, nil
+}
+
+//==============================================================================
+
+// xpError defines the error returned for invalid expressions.
+type xpError string
+
+// Error returns the error message.
+func (x xpError) Error() string {
+	return string(x)
+}
+
+func xpErrorf(format string, args ...interface{}) xpError {
+	return xpError("xpath: " + fmt.Sprintf(format, args...))
+}
+
+//==============================================================================
+
+type xpNodeKind int
+
+const (
+	xpDocumentNode xpNodeKind = iota
+	xpElementNode
+	xpTextNode
+	xpCommentNode
+	xpAttributeNode
+)
+
+// xpNode defines a node of the evaluated tree, attribute nodes are represented
+// by their owner markup and property.
+type xpNode struct {
+	kind xpNodeKind
+	m    *Markup
+	attr Property
+}
+
+// xpDocument defines the document the evaluation occurs within.
+type xpDocument struct {
+	top   *Markup
+	order map[*Markup]int
+}
+
+func (d *xpDocument) nodeFor(m *Markup) xpNode {
+	if m.tagname != "text" {
+		return xpNode{kind: xpElementNode, m: m}
+	}
+
+	if content := m.TextContent(); strings.HasPrefix(content, "<!--") && strings.HasSuffix(content, "-->") {
+		return xpNode{kind: xpCommentNode, m: m}
+	}
+
+	return xpNode{kind: xpTextNode, m: m}
+}
+
+// position returns the position of the node in document order.
+func (d *xpDocument) position(n xpNode) (int, int) {
+	if n.kind == xpDocumentNode {
+		return 0, 0
+	}
+
+	if d.order == nil {
+		d.order = make(map[*Markup]int)
+
+		count := 1
+		d.order[d.top] = count
+		d.top.EachChild(func(m *Markup) {
+			count++
+			d.order[m] = count
+		})
+	}
+
+	if n.kind != xpAttributeNode {
+		return d.order[n.m], 0
+	}
+
+	for index, attr := range n.m.attrs {
+		if attr == n.attr {
+			return d.order[n.m], index + 1
+		}
+	}
+
+	return d.order[n.m], len(n.m.attrs) + 1
+}
+
+func (d *xpDocument) sort(nodes []xpNode) xpNodeSet {
+	seen := make(map[xpNode]bool, len(nodes))
+	set := make(xpNodeSet, 0, len(nodes))
+
+	for _, node := range nodes {
+		if seen[node] {
+			continue
+		}
+
+		seen[node] = true
+		set = append(set, node)
+	}
+
+	sort.SliceStable(set, func(i, j int) bool {
+		first, firstSub := d.position(set[i])
+		second, secondSub := d.position(set[j])
+
+		if first != second {
+			return first < second
+		}
+
+		return firstSub < secondSub
+	})
+
+	return set
+}
+
+func (d *xpDocument) children(n xpNode) []xpNode {
+	if n.kind == xpDocumentNode {
+		return []xpNode{d.nodeFor(d.top)}
+	}
+
+	if n.kind != xpElementNode {
+		return nil
+	}
+
+	nodes := make([]xpNode, 0, len(n.m.children))
+	for _, child := range n.m.children {
+		nodes = append(nodes, d.nodeFor(child))
+	}
+
+	return nodes
+}
+
+func (d *xpDocument) parent(n xpNode) (xpNode, bool) {
+	switch {
+	case n.kind == xpDocumentNode:
+		return xpNode{}, false
+	case n.kind == xpAttributeNode:
+		return d.nodeFor(n.m), true
+	case n.m == d.top:
+		return xpNode{kind: xpDocumentNode}, true
+	case n.m.parent == nil:
+		return xpNode{}, false
+	}
+
+	return d.nodeFor(n.m.parent), true
+}
+
+func (d *xpDocument) descendants(n xpNode, nodes []xpNode) []xpNode {
+	for _, child := range d.children(n) {
+		nodes = append(nodes, child)
+		nodes = d.descendants(child, nodes)
+	}
+
+	return nodes
+}
+
+func (d *xpDocument) siblings(n xpNode) ([]xpNode, int) {
+	if n.kind == xpDocumentNode || n.kind == xpAttributeNode {
+		return nil, -1
+	}
+
+	parent, ok := d.parent(n)
+	if !ok {
+		return nil, -1
+	}
+
+	children := d.children(parent)
+	for index, child := range children {
+		if child == n {
+			return children, index
+		}
+	}
+
+	return nil, -1
+}
+
+// stringValue returns the string-value of the node.
+func (d *xpDocument) stringValue(n xpNode) string {
+	switch n.kind {
+	case xpDocumentNode:
+		return d.stringValue(d.nodeFor(d.top))
+	case xpAttributeNode:
+		_, value := n.attr.Render()
+		return value
+	case xpCommentNode:
+		content := n.m.TextContent()
+		return strings.TrimSuffix(strings.TrimPrefix(content, "<!--"), "-->")
+	case xpTextNode:
+		return n.m.TextContent()
+	}
+
+	var content []string
+	if text := n.m.TextContent(); text != "" {
+		content = append(content, text)
+	}
+
+	for _, child := range d.children(n) {
+		if child.kind == xpCommentNode {
+			continue
+		}
+
+		content = append(content, d.stringValue(child))
+	}
+
+	return strings.Join(content, "")
+}
+
+func (d *xpDocument) name(n xpNode) string {
+	switch n.kind {
+	case xpElementNode:
+		return n.m.tagname
+	case xpAttributeNode:
+		name, _ := n.attr.Render()
+		return name
+	}
+
+	return ""
+}
+
+//==============================================================================
+
+// xpValue is one of xpNodeSet, string, float64 or bool.
+type xpValue interface{}
+
+// xpNodeSet defines a set of nodes in document order.
+type xpNodeSet []xpNode
+
+func (s xpNodeSet) markup() []*Markup {
+	var found []*Markup
+
+	for _, node := range s {
+		switch node.kind {
+		case xpAttributeNode:
+			continue
+		case xpDocumentNode:
+			if len(s) == 1 {
+				return nil
+			}
+			continue
+		}
+
+		found = append(found, node.m)
+	}
+
+	return found
+}
+
+func (s xpNodeSet) attributes() []Property {
+	if len(s) == 0 {
+		return nil
+	}
+
+	var found []Property
+	for _, node := range s {
+		if node.kind != xpAttributeNode {
+			return nil
+		}
+
+		found = append(found, node.attr)
+	}
+
+	return found
+}
+
+// xpContext defines the evaluation context of a expression.
+type xpContext struct {
+	doc      *xpDocument
+	node     xpNode
+	position int
+	size     int
+}
+
+func (c *xpContext) toString(v xpValue) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case bool:
+		if value {
+			return "true"
+		}
+		return "false"
+	case float64:
+		return xpFormatNumber(value)
+	case xpNodeSet:
+		if len(value) == 0 {
+			return ""
+		}
+		return c.doc.stringValue(value[0])
+	}
+
+	return ""
+}
+
+func (c *xpContext) toNumber(v xpValue) float64 {
+	switch value := v.(type) {
+	case float64:
+		return value
+	case bool:
+		if value {
+			return 1
+		}
+		return 0
+	case string:
+		return xpParseNumber(value)
+	case xpNodeSet:
+		return xpParseNumber(c.toString(value))
+	}
+
+	return math.NaN()
+}
+
+func (c *xpContext) toBool(v xpValue) bool {
+	switch value := v.(type) {
+	case bool:
+		return value
+	case float64:
+		return value != 0 && !math.IsNaN(value)
+	case string:
+		return value != ""
+	case xpNodeSet:
+		return len(value) != 0
+	}
+
+	return false
+}
+
+func (c *xpContext) toNodeSet(v xpValue) xpNodeSet {
+	set, ok := v.(xpNodeSet)
+	if !ok {
+		panic(xpErrorf("expected node-set but got %T", v))
+	}
+
+	return set
+}
+
+func xpParseNumber(value string) float64 {
+	value = strings.TrimSpace(value)
+
+	// only an optional minus sign followed by digits and a single dot is valid.
+	digits := strings.TrimPrefix(value, "-")
+	if digits == "" || digits == "." || strings.Trim(digits, "0123456789.") != "" || strings.Count(digits, ".") > 1 {
+		return math.NaN()
+	}
+
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return num
+}
+
+func xpFormatNumber(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	case value == 0:
+		return "0"
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+//==============================================================================
+
+type xpTokenKind int
+
+const (
+	xpEOF xpTokenKind = iota
+	xpName
+	xpNumber
+	xpLiteral
+	xpOperator
+	xpPunct
+	xpStar
+	xpVariable
+)
+
+type xpToken struct {
+	kind  xpTokenKind
+	value string
+	pos   int
+}
+
+// lexXPath splits the expression into tokens disambiguating operators as
+// specified by the XPath 1.0 lexical rules.
+func lexXPath(expr string) ([]xpToken, error) {
+	var tokens []xpToken
+	runes := []rune(expr)
+
+	// operatorNext reports if the next '*' or name must be read as an operator.
+	operatorNext := func() bool {
+		if len(tokens) == 0 {
+			return false
+		}
+
+		last := tokens[len(tokens)-1]
+		switch last.kind {
+		case xpOperator:
+			return false
+		case xpPunct:
+			switch last.value {
+			case "@", "::", "(", "[", ",":
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for index := 0; index < len(runes); {
+		char := runes[index]
+
+		switch {
+		case unicode.IsSpace(char):
+			index++
+			continue
+
+		case char == '"' || char == '\'':
+			end := index + 1
+			for end < len(runes) && runes[end] != char {
+				end++
+			}
+
+			if end >= len(runes) {
+				return nil, xpErrorf("unterminated literal at %d in %q", index, expr)
+			}
+
+			tokens = append(tokens, xpToken{kind: xpLiteral, value: string(runes[index+1 : end]), pos: index})
+			index = end + 1
+			continue
+
+		case unicode.IsDigit(char) || char == '.' && index+1 < len(runes) && unicode.IsDigit(runes[index+1]):
+			end := index
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+
+			tokens = append(tokens, xpToken{kind: xpNumber, value: string(runes[index:end]), pos: index})
+			index = end
+			continue
+
+		case char == '*':
+			if operatorNext() {
+				tokens = append(tokens, xpToken{kind: xpOperator, value: "*", pos: index})
+			} else {
+				tokens = append(tokens, xpToken{kind: xpStar, value: "*", pos: index})
+			}
+
+			index++
+			continue
+
+		case char == '$':
+			end := index + 1
+			for end < len(runes) && xpNameChar(runes[end]) {
+				end++
+			}
+
+			tokens = append(tokens, xpToken{kind: xpVariable, value: string(runes[index+1 : end]), pos: index})
+			index = end
+			continue
+
+		case xpNameStart(char):
+			end := index
+			for end < len(runes) && xpNameChar(runes[end]) {
+				end++
+			}
+
+			// allow qualified names and prefixed wildcards (prefix:name, prefix:*).
+			if end+1 < len(runes) && runes[end] == ':' && runes[end+1] != ':' {
+				if runes[end+1] == '*' {
+					end += 2
+				} else if xpNameStart(runes[end+1]) {
+					end++
+					for end < len(runes) && xpNameChar(runes[end]) {
+						end++
+					}
+				}
+			}
+
+			name := string(runes[index:end])
+			if operatorNext() {
+				switch name {
+				case "and", "or", "mod", "div":
+					tokens = append(tokens, xpToken{kind: xpOperator, value: name, pos: index})
+					index = end
+					continue
+				}
+			}
+
+			tokens = append(tokens, xpToken{kind: xpName, value: name, pos: index})
+			index = end
+			continue
+		}
+
+		two := ""
+		if index+1 < len(runes) {
+			two = string(runes[index : index+2])
+		}
+
+		switch two {
+		case "//", "!=", "<=", ">=":
+			tokens = append(tokens, xpToken{kind: xpOperator, value: two, pos: index})
+			index += 2
+			continue
+		case "::", "..":
+			tokens = append(tokens, xpToken{kind: xpPunct, value: two, pos: index})
+			index += 2
+			continue
+		}
+
+		switch char {
+		case '/', '|', '+', '-', '=', '<', '>':
+			tokens = append(tokens, xpToken{kind: xpOperator, value: string(char), pos: index})
+		case '(', ')', '[', ']', '.', '@', ',':
+			tokens = append(tokens, xpToken{kind: xpPunct, value: string(char), pos: index})
+		default:
+			return nil, xpErrorf("unexpected character %q at %d in %q", char, index, expr)
+		}
+
+		index++
+	}
+
+	tokens = append(tokens, xpToken{kind: xpEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+func xpNameStart(char rune) bool {
+	return char == '_' || unicode.IsLetter(char)
+}
+
+func xpNameChar(char rune) bool {
+	return xpNameStart(char) || unicode.IsDigit(char) || char == '-' || char == '.'
+}
+
+//==============================================================================
+
+// xpExpr defines a node of a parsed expression.
+type xpExpr interface {
+	eval(*xpContext) xpValue
+}
+
+type xpParser struct {
+	source string
+	tokens []xpToken
+	index  int
+}
+
+func (p *xpParser) parse() (expr xpExpr, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			xerr, ok := rec.(xpError)
+			if !ok {
+				panic(rec)
+			}
+
+			expr, err = nil, xerr
+		}
+	}()
+
+	expr = p.parseOr()
+	if tok := p.peek(); tok.kind != xpEOF {
+		p.fail(tok, "unexpected token %q", tok.value)
+	}
+
+	return expr, nil
+}
+
+func (p *xpParser) peek() xpToken {
+	return p.tokens[p.index]
+}
+
+func (p *xpParser) peekAt(offset int) xpToken {
+	if p.index+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+
+	return p.tokens[p.index+offset]
+}
+
+func (p *xpParser) next() xpToken {
+	tok := p.tokens[p.index]
+	if tok.kind != xpEOF {
+		p.index++
+	}
+
+	return tok
+}
+
+func (p *xpParser) is(kind xpTokenKind, value string) bool {
+	tok := p.peek()
+	return tok.kind == kind && tok.value == value
+}
+
+func (p *xpParser) expect(kind xpTokenKind, value string) {
+	if tok := p.next(); tok.kind != kind || tok.value != value {
+		p.fail(tok, "expected %q but got %q", value, tok.value)
+	}
+}
+
+func (p *xpParser) fail(tok xpToken, format string, args ...interface{}) {
+	panic(xpErrorf("%s at %d in %q", fmt.Sprintf(format, args...), tok.pos, p.source))
+}
+
+func (p *xpParser) parseBinary(next func() xpExpr, ops ...string) xpExpr {
+	left := next()
+
+	for {
+		tok := p.peek()
+		if tok.kind != xpOperator {
+			return left
+		}
+
+		var matched bool
+		for _, op := range ops {
+			if tok.value == op {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return left
+		}
+
+		p.next()
+		left = &xpBinary{op: tok.value, left: left, right: next()}
+	}
+}
+
+func (p *xpParser) parseOr() xpExpr {
+	return p.parseBinary(p.parseAnd, "or")
+}
+
+func (p *xpParser) parseAnd() xpExpr {
+	return p.parseBinary(p.parseEquality, "and")
+}
+
+func (p *xpParser) parseEquality() xpExpr {
+	return p.parseBinary(p.parseRelational, "=", "!=")
+}
+
+func (p *xpParser) parseRelational() xpExpr {
+	return p.parseBinary(p.parseAdditive, "<", "<=", ">", ">=")
+}
+
+func (p *xpParser) parseAdditive() xpExpr {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *xpParser) parseMultiplicative() xpExpr {
+	return p.parseBinary(p.parseUnary, "*", "div", "mod")
+}
+
+func (p *xpParser) parseUnary() xpExpr {
+	if p.is(xpOperator, "-") {
+		p.next()
+		return &xpNegate{expr: p.parseUnary()}
+	}
+
+	return p.parseUnion()
+}
+
+func (p *xpParser) parseUnion() xpExpr {
+	return p.parseBinary(p.parsePath, "|")
+}
+
+func (p *xpParser) parsePath() xpExpr {
+	tok := p.peek()
+
+	filter := false
+	switch tok.kind {
+	case xpLiteral, xpNumber, xpVariable:
+		filter = true
+	case xpPunct:
+		filter = tok.value == "("
+	case xpName:
+		// a name followed by '(' is a function call unless it is a node type test.
+		if next := p.peekAt(1); next.kind == xpPunct && next.value == "(" {
+			switch tok.value {
+			case "node", "text", "comment", "processing-instruction":
+			default:
+				filter = true
+			}
+		}
+	}
+
+	if !filter {
+		return p.parseLocationPath()
+	}
+
+	expr := p.parsePrimary()
+
+	var predicates []xpExpr
+	for p.is(xpPunct, "[") {
+		predicates = append(predicates, p.parsePredicate())
+	}
+
+	if predicates != nil {
+		expr = &xpFilter{expr: expr, predicates: predicates}
+	}
+
+	if !p.is(xpOperator, "/") && !p.is(xpOperator, "//") {
+		return expr
+	}
+
+	return &xpPath{filter: expr, steps: p.parseRelativeSteps(nil)}
+}
+
+func (p *xpParser) parseLocationPath() xpExpr {
+	path := &xpPath{}
+
+	switch {
+	case p.is(xpOperator, "/"):
+		p.next()
+		path.absolute = true
+
+		// a single '/' selects the document node.
+		if !p.startsStep() {
+			return path
+		}
+
+		path.steps = p.parseRelativeSteps([]*xpStep{p.parseStep()})
+	case p.is(xpOperator, "//"):
+		p.next()
+		path.absolute = true
+		path.steps = p.parseRelativeSteps([]*xpStep{descendantOrSelfStep(), p.parseStep()})
+	default:
+		path.steps = p.parseRelativeSteps([]*xpStep{p.parseStep()})
+	}
+
+	return path
+}
+
+func (p *xpParser) startsStep() bool {
+	tok := p.peek()
+	switch tok.kind {
+	case xpName, xpStar:
+		return true
+	case xpPunct:
+		return tok.value == "." || tok.value == ".." || tok.value == "@"
+	}
+
+	return false
+}
+
+func (p *xpParser) parseRelativeSteps(steps []*xpStep) []*xpStep {
+	for {
+		switch {
+		case p.is(xpOperator, "/"):
+			p.next()
+			steps = append(steps, p.parseStep())
+		case p.is(xpOperator, "//"):
+			p.next()
+			steps = append(steps, descendantOrSelfStep(), p.parseStep())
+		default:
+			return steps
+		}
+	}
+}
+
+func descendantOrSelfStep() *xpStep {
+	return &xpStep{axis: "descendant-or-self", test: xpNodeTest{nodeType: "node"}}
+}
+
+func (p *xpParser) parseStep() *xpStep {
+	switch {
+	case p.is(xpPunct, "."):
+		p.next()
+		return &xpStep{axis: "self", test: xpNodeTest{nodeType: "node"}}
+	case p.is(xpPunct, ".."):
+		p.next()
+		return &xpStep{axis: "parent", test: xpNodeTest{nodeType: "node"}}
+	}
+
+	step := &xpStep{axis: "child"}
+
+	if p.is(xpPunct, "@") {
+		p.next()
+		step.axis = "attribute"
+	} else if tok := p.peek(); tok.kind == xpName && p.peekAt(1).kind == xpPunct && p.peekAt(1).value == "::" {
+		if _, ok := xpAxes[tok.value]; !ok {
+			p.fail(tok, "unknown axis %q", tok.value)
+		}
+
+		p.next()
+		p.next()
+		step.axis = tok.value
+	}
+
+	tok := p.next()
+	switch tok.kind {
+	case xpStar:
+		step.test = xpNodeTest{name: "*"}
+	case xpName:
+		if p.is(xpPunct, "(") {
+			switch tok.value {
+			case "node", "text", "comment", "processing-instruction":
+			default:
+				p.fail(tok, "unknown node type %q", tok.value)
+			}
+
+			p.next()
+			if tok.value == "processing-instruction" && p.peek().kind == xpLiteral {
+				p.next()
+			}
+
+			p.expect(xpPunct, ")")
+			step.test = xpNodeTest{nodeType: tok.value}
+			break
+		}
+
+		step.test = xpNodeTest{name: strings.ToLower(tok.value)}
+	default:
+		p.fail(tok, "expected node test but got %q", tok.value)
+	}
+
+	for p.is(xpPunct, "[") {
+		step.predicates = append(step.predicates, p.parsePredicate())
+	}
+
+	return step
+}
+
+func (p *xpParser) parsePredicate() xpExpr {
+	p.expect(xpPunct, "[")
+	expr := p.parseOr()
+	p.expect(xpPunct, "]")
+	return expr
+}
+
+func (p *xpParser) parsePrimary() xpExpr {
+	tok := p.next()
+
+	switch tok.kind {
+	case xpLiteral:
+		return xpLiteralExpr(tok.value)
+	case xpNumber:
+		return xpNumberExpr(xpParseNumber(tok.value))
+	case xpVariable:
+		p.fail(tok, "variables are not supported")
+	case xpPunct:
+		expr := p.parseOr()
+		p.expect(xpPunct, ")")
+		return expr
+	case xpName:
+		fn, ok := xpFunctions[tok.value]
+		if !ok {
+			p.fail(tok, "unknown function %q", tok.value)
+		}
+
+		p.expect(xpPunct, "(")
+
+		call := &xpCall{name: tok.value, fn: fn}
+		if !p.is(xpPunct, ")") {
+			call.args = append(call.args, p.parseOr())
+			for p.is(xpPunct, ",") {
+				p.next()
+				call.args = append(call.args, p.parseOr())
+			}
+		}
+
+		p.expect(xpPunct, ")")
+
+		if len(call.args) < fn.min || fn.max >= 0 && len(call.args) > fn.max {
+			p.fail(tok, "invalid number of arguments for %s()", tok.value)
+		}
+
+		return call
+	}
+
+	p.fail(tok, "unexpected token %q", tok.value)
+	return nil
+}
+
+//==============================================================================
+
+type xpLiteralExpr string
+
+func (x xpLiteralExpr) eval(*xpContext) xpValue {
+	return string(x)
+}
+
+type xpNumberExpr float64
+
+func (x xpNumberExpr) eval(*xpContext) xpValue {
+	return float64(x)
+}
+
+type xpNegate struct {
+	expr xpExpr
+}
+
+func (x *xpNegate) eval(ctx *xpContext) xpValue {
+	return -ctx.toNumber(x.expr.eval(ctx))
+}
+
+type xpBinary struct {
+	op    string
+	left  xpExpr
+	right xpExpr
+}
+
+func (x *xpBinary) eval(ctx *xpContext) xpValue {
+	switch x.op {
+	case "or":
+		return ctx.toBool(x.left.eval(ctx)) || ctx.toBool(x.right.eval(ctx))
+	case "and":
+		return ctx.toBool(x.left.eval(ctx)) && ctx.toBool(x.right.eval(ctx))
+	case "|":
+		left := ctx.toNodeSet(x.left.eval(ctx))
+		right := ctx.toNodeSet(x.right.eval(ctx))
+		return ctx.doc.sort(append(append([]xpNode(nil), left...), right...))
+	case "=", "!=", "<", "<=", ">", ">=":
+		return xpCompare(ctx, x.op, x.left.eval(ctx), x.right.eval(ctx))
+	}
+
+	left := ctx.toNumber(x.left.eval(ctx))
+	right := ctx.toNumber(x.right.eval(ctx))
+
+	switch x.op {
+	case "+":
+		return left + right
+	case "-":
+		return left - right
+	case "*":
+		return left * right
+	case "div":
+		return left / right
+	}
+
+	return math.Mod(left, right)
+}
+
+// xpCompare compares the giving values following the XPath 1.0 rules for
+// comparisons between node-sets and other types.
+func xpCompare(ctx *xpContext, op string, left, right xpValue) bool {
+	leftSet, leftIsSet := left.(xpNodeSet)
+	rightSet, rightIsSet := right.(xpNodeSet)
+
+	switch {
+	case leftIsSet && rightIsSet:
+		for _, ln := range leftSet {
+			for _, rn := range rightSet {
+				if xpCompareValues(ctx, op, ctx.doc.stringValue(ln), ctx.doc.stringValue(rn)) {
+					return true
+				}
+			}
+		}
+
+		return false
+
+	case leftIsSet || rightIsSet:
+		set, other := leftSet, right
+		if rightIsSet {
+			set, other = rightSet, left
+		}
+
+		if value, ok := other.(bool); ok {
+			if rightIsSet {
+				return xpCompareValues(ctx, op, value, ctx.toBool(set))
+			}
+
+			return xpCompareValues(ctx, op, ctx.toBool(set), value)
+		}
+
+		for _, node := range set {
+			var nodeValue xpValue = ctx.doc.stringValue(node)
+			if _, ok := other.(float64); ok {
+				nodeValue = ctx.toNumber(nodeValue)
+			}
+
+			if rightIsSet && xpCompareValues(ctx, op, other, nodeValue) {
+				return true
+			}
+
+			if leftIsSet && xpCompareValues(ctx, op, nodeValue, other) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return xpCompareValues(ctx, op, left, right)
+}
+
+func xpCompareValues(ctx *xpContext, op string, left, right xpValue) bool {
+	switch op {
+	case "=", "!=":
+		var equal bool
+
+		_, leftBool := left.(bool)
+		_, rightBool := right.(bool)
+		_, leftNum := left.(float64)
+		_, rightNum := right.(float64)
+
+		switch {
+		case leftBool || rightBool:
+			equal = ctx.toBool(left) == ctx.toBool(right)
+		case leftNum || rightNum:
+			equal = ctx.toNumber(left) == ctx.toNumber(right)
+		default:
+			equal = ctx.toString(left) == ctx.toString(right)
+		}
+
+		if op == "=" {
+			return equal
+		}
+
+		return !equal
+	}
+
+	first, second := ctx.toNumber(left), ctx.toNumber(right)
+
+	switch op {
+	case "<":
+		return first < second
+	case "<=":
+		return first <= second
+	case ">":
+		return first > second
+	}
+
+	return first >= second
+}
+
+type xpFilter struct {
+	expr       xpExpr
+	predicates []xpExpr
+}
+
+func (x *xpFilter) eval(ctx *xpContext) xpValue {
+	set := ctx.toNodeSet(x.expr.eval(ctx))
+
+	nodes := []xpNode(set)
+	for _, predicate := range x.predicates {
+		nodes = xpApplyPredicate(ctx, predicate, nodes)
+	}
+
+	return xpNodeSet(nodes)
+}
+
+// xpApplyPredicate returns the nodes which match the predicate, where the nodes
+// are expected to be in the order of the axis they were selected with.
+func xpApplyPredicate(ctx *xpContext, predicate xpExpr, nodes []xpNode) []xpNode {
+	var found []xpNode
+
+	for index, node := range nodes {
+		sub := &xpContext{doc: ctx.doc, node: node, position: index + 1, size: len(nodes)}
+
+		value := predicate.eval(sub)
+		if num, ok := value.(float64); ok {
+			if num == float64(index+1) {
+				found = append(found, node)
+			}
+			continue
+		}
+
+		if sub.toBool(value) {
+			found = append(found, node)
+		}
+	}
+
+	return found
+}
+
+type xpPath struct {
+	absolute bool
+	filter   xpExpr
+	steps    []*xpStep
+}
+
+func (x *xpPath) eval(ctx *xpContext) xpValue {
+	var current xpNodeSet
+
+	switch {
+	case x.filter != nil:
+		current = ctx.toNodeSet(x.filter.eval(ctx))
+	case x.absolute:
+		current = xpNodeSet{{kind: xpDocumentNode}}
+	default:
+		current = xpNodeSet{ctx.node}
+	}
+
+	for _, step := range x.steps {
+		var nodes []xpNode
+		for _, node := range current {
+			nodes = append(nodes, step.apply(ctx.doc, node)...)
+		}
+
+		current = ctx.doc.sort(nodes)
+	}
+
+	return current
+}
+
+//==============================================================================
+
+var xpAxes = map[string]bool{
+	"ancestor":           true,
+	"ancestor-or-self":   true,
+	"attribute":          true,
+	"child":              true,
+	"descendant":         true,
+	"descendant-or-self": true,
+	"following":          true,
+	"following-sibling":  true,
+	"namespace":          true,
+	"parent":             true,
+	"preceding":          true,
+	"preceding-sibling":  true,
+	"self":               true,
+}
+
+type xpNodeTest struct {
+	name     string
+	nodeType string
+}
+
+func (t xpNodeTest) match(doc *xpDocument, node xpNode, axis string) bool {
+	switch t.nodeType {
+	case "node":
+		return true
+	case "text":
+		return node.kind == xpTextNode
+	case "comment":
+		return node.kind == xpCommentNode
+	case "processing-instruction":
+		return false
+	}
+
+	// the principal node type is attribute for the attribute axis and element
+	// for every other.
+	if axis == "attribute" {
+		if node.kind != xpAttributeNode {
+			return false
+		}
+	} else if node.kind != xpElementNode {
+		return false
+	}
+
+	if t.name == "*" {
+		return true
+	}
+
+	return strings.ToLower(doc.name(node)) == t.name
+}
+
+type xpStep struct {
+	axis       string
+	test       xpNodeTest
+	predicates []xpExpr
+}
+
+// apply returns the nodes selected by the step from the giving node.
+func (s *xpStep) apply(doc *xpDocument, node xpNode) []xpNode {
+	var found []xpNode
+
+	for _, item := range s.axisNodes(doc, node) {
+		if s.test.match(doc, item, s.axis) {
+			found = append(found, item)
+		}
+	}
+
+	for _, predicate := range s.predicates {
+		found = xpApplyPredicate(&xpContext{doc: doc}, predicate, found)
+	}
+
+	return found
+}
+
+// axisNodes returns the nodes of the axis in axis order, where reverse axes
+// return the nodes nearest to the context node first.
+func (s *xpStep) axisNodes(doc *xpDocument, node xpNode) []xpNode {
+	switch s.axis {
+	case "self":
+		return []xpNode{node}
+
+	case "child":
+		return doc.children(node)
+
+	case "attribute":
+		if node.kind != xpElementNode {
+			return nil
+		}
+
+		nodes := make([]xpNode, 0, len(node.m.attrs))
+		for _, attr := range node.m.attrs {
+			nodes = append(nodes, xpNode{kind: xpAttributeNode, m: node.m, attr: attr})
+		}
+
+		return nodes
+
+	case "descendant":
+		return doc.descendants(node, nil)
+
+	case "descendant-or-self":
+		return doc.descendants(node, []xpNode{node})
+
+	case "parent":
+		if parent, ok := doc.parent(node); ok {
+			return []xpNode{parent}
+		}
+
+		return nil
+
+	case "ancestor", "ancestor-or-self":
+		var nodes []xpNode
+		if s.axis == "ancestor-or-self" {
+			nodes = append(nodes, node)
+		}
+
+		for parent, ok := doc.parent(node); ok; parent, ok = doc.parent(parent) {
+			nodes = append(nodes, parent)
+		}
+
+		return nodes
+
+	case "following-sibling":
+		siblings, index := doc.siblings(node)
+		if index == -1 {
+			return nil
+		}
+
+		return append([]xpNode(nil), siblings[index+1:]...)
+
+	case "preceding-sibling":
+		siblings, index := doc.siblings(node)
+		if index == -1 {
+			return nil
+		}
+
+		var nodes []xpNode
+		for n := index - 1; n >= 0; n-- {
+			nodes = append(nodes, siblings[n])
+		}
+
+		return nodes
+
+	case "following":
+		var nodes []xpNode
+
+		// attributes are followed by the children of their owner.
+		current := node
+		if node.kind == xpAttributeNode {
+			current = doc.nodeFor(node.m)
+			nodes = doc.descendants(current, nodes)
+		}
+
+		for ; ; current, _ = doc.parent(current) {
+			siblings, index := doc.siblings(current)
+			if index == -1 {
+				break
+			}
+
+			for _, sibling := range siblings[index+1:] {
+				nodes = append(nodes, sibling)
+				nodes = doc.descendants(sibling, nodes)
+			}
+		}
+
+		return nodes
+
+	case "preceding":
+		// preceding excludes ancestors, so collect every node before the
+		// context node in document order which is not an ancestor.
+		ancestors := make(map[xpNode]bool)
+		for parent, ok := doc.parent(node); ok; parent, ok = doc.parent(parent) {
+			ancestors[parent] = true
+		}
+
+		if node.kind == xpAttributeNode {
+			node = doc.nodeFor(node.m)
+		}
+
+		var before []xpNode
+		for _, item := range doc.descendants(xpNode{kind: xpDocumentNode}, nil) {
+			if item == node {
+				break
+			}
+
+			if !ancestors[item] {
+				before = append(before, item)
+			}
+		}
+
+		for i, j := 0, len(before)-1; i < j; i, j = i+1, j-1 {
+			before[i], before[j] = before[j], before[i]
+		}
+
+		return before
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+type xpFunction struct {
+	min  int
+	max  int
+	call func(ctx *xpContext, args []xpExpr) xpValue
+}
+
+type xpCall struct {
+	name string
+	fn   xpFunction
+	args []xpExpr
+}
+
+func (x *xpCall) eval(ctx *xpContext) xpValue {
+	return x.fn.call(ctx, x.args)
+}
+
+// xpStringArg returns the string value of the argument at the index or the
+// string-value of the context node if not provided.
+func xpStringArg(ctx *xpContext, args []xpExpr, index int) string {
+	if index >= len(args) {
+		return ctx.doc.stringValue(ctx.node)
+	}
+
+	return ctx.toString(args[index].eval(ctx))
+}
+
+// xpNodeArg returns the first node of the node-set argument or the context node
+// if not provided.
+func xpNodeArg(ctx *xpContext, args []xpExpr) (xpNode, bool) {
+	if len(args) == 0 {
+		return ctx.node, true
+	}
+
+	set := ctx.toNodeSet(args[0].eval(ctx))
+	if len(set) == 0 {
+		return xpNode{}, false
+	}
+
+	return set[0], true
+}
+
+var xpFunctions map[string]xpFunction
+
+func init() {
+	xpFunctions = map[string]xpFunction{
+		"last": {0, 0, func(ctx *xpContext, args []xpExpr) xpValue {
+			return float64(ctx.size)
+		}},
+		"position": {0, 0, func(ctx *xpContext, args []xpExpr) xpValue {
+			return float64(ctx.position)
+		}},
+		"count": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return float64(len(ctx.toNodeSet(args[0].eval(ctx))))
+		}},
+		"id": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			var ids []string
+
+			if set, ok := args[0].eval(ctx).(xpNodeSet); ok {
+				for _, node := range set {
+					ids = append(ids, strings.Fields(ctx.doc.stringValue(node))...)
+				}
+			} else {
+				ids = strings.Fields(xpStringArg(ctx, args, 0))
+			}
+
+			var nodes []xpNode
+			for _, id := range ids {
+				for _, item := range ctx.doc.descendants(xpNode{kind: xpDocumentNode}, nil) {
+					if item.kind != xpElementNode {
+						continue
+					}
+
+					if attr, err := GetAttr(item.m, "id"); err == nil {
+						if _, value := attr.Render(); value == id {
+							nodes = append(nodes, item)
+							break
+						}
+					}
+				}
+			}
+
+			return ctx.doc.sort(nodes)
+		}},
+		"local-name": {0, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			node, ok := xpNodeArg(ctx, args)
+			if !ok {
+				return ""
+			}
+
+			name := ctx.doc.name(node)
+			if index := strings.Index(name, ":"); index != -1 {
+				return name[index+1:]
+			}
+
+			return name
+		}},
+		"name": {0, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			node, ok := xpNodeArg(ctx, args)
+			if !ok {
+				return ""
+			}
+
+			return ctx.doc.name(node)
+		}},
+		"namespace-uri": {0, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return ""
+		}},
+		"string": {0, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return xpStringArg(ctx, args, 0)
+		}},
+		"concat": {2, -1, func(ctx *xpContext, args []xpExpr) xpValue {
+			var parts []string
+			for index := range args {
+				parts = append(parts, xpStringArg(ctx, args, index))
+			}
+
+			return strings.Join(parts, "")
+		}},
+		"starts-with": {2, 2, func(ctx *xpContext, args []xpExpr) xpValue {
+			return strings.HasPrefix(xpStringArg(ctx, args, 0), xpStringArg(ctx, args, 1))
+		}},
+		"contains": {2, 2, func(ctx *xpContext, args []xpExpr) xpValue {
+			return strings.Contains(xpStringArg(ctx, args, 0), xpStringArg(ctx, args, 1))
+		}},
+		"substring-before": {2, 2, func(ctx *xpContext, args []xpExpr) xpValue {
+			value, sep := xpStringArg(ctx, args, 0), xpStringArg(ctx, args, 1)
+			if index := strings.Index(value, sep); index != -1 {
+				return value[:index]
+			}
+
+			return ""
+		}},
+		"substring-after": {2, 2, func(ctx *xpContext, args []xpExpr) xpValue {
+			value, sep := xpStringArg(ctx, args, 0), xpStringArg(ctx, args, 1)
+			if index := strings.Index(value, sep); index != -1 {
+				return value[index+len(sep):]
+			}
+
+			return ""
+		}},
+		"substring": {2, 3, func(ctx *xpContext, args []xpExpr) xpValue {
+			value := []rune(xpStringArg(ctx, args, 0))
+			start := xpRound(ctx.toNumber(args[1].eval(ctx)))
+
+			end := math.Inf(1)
+			if len(args) == 3 {
+				end = start + xpRound(ctx.toNumber(args[2].eval(ctx)))
+			}
+
+			var found []rune
+			for index, char := range value {
+				position := float64(index + 1)
+				if position >= start && position < end {
+					found = append(found, char)
+				}
+			}
+
+			return string(found)
+		}},
+		"string-length": {0, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return float64(len([]rune(xpStringArg(ctx, args, 0))))
+		}},
+		"normalize-space": {0, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return strings.Join(strings.Fields(xpStringArg(ctx, args, 0)), " ")
+		}},
+		"translate": {3, 3, func(ctx *xpContext, args []xpExpr) xpValue {
+			value := xpStringArg(ctx, args, 0)
+			from := []rune(xpStringArg(ctx, args, 1))
+			to := []rune(xpStringArg(ctx, args, 2))
+
+			return strings.Map(func(char rune) rune {
+				for index, item := range from {
+					if item != char {
+						continue
+					}
+
+					if index < len(to) {
+						return to[index]
+					}
+
+					return -1
+				}
+
+				return char
+			}, value)
+		}},
+		"boolean": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return ctx.toBool(args[0].eval(ctx))
+		}},
+		"not": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return !ctx.toBool(args[0].eval(ctx))
+		}},
+		"true": {0, 0, func(ctx *xpContext, args []xpExpr) xpValue {
+			return true
+		}},
+		"false": {0, 0, func(ctx *xpContext, args []xpExpr) xpValue {
+			return false
+		}},
+		"lang": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			lang := strings.ToLower(xpStringArg(ctx, args, 0))
+
+			for node, ok := ctx.node, true; ok; node, ok = ctx.doc.parent(node) {
+				if node.kind != xpElementNode {
+					continue
+				}
+
+				attr, err := GetAttr(node.m, "lang")
+				if err != nil {
+					continue
+				}
+
+				_, value := attr.Render()
+				value = strings.ToLower(value)
+				return value == lang || strings.HasPrefix(value, lang+"-")
+			}
+
+			return false
+		}},
+		"number": {0, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			if len(args) == 0 {
+				return xpParseNumber(ctx.doc.stringValue(ctx.node))
+			}
+
+			return ctx.toNumber(args[0].eval(ctx))
+		}},
+		"sum": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			var total float64
+			for _, node := range ctx.toNodeSet(args[0].eval(ctx)) {
+				total += xpParseNumber(ctx.doc.stringValue(node))
+			}
+
+			return total
+		}},
+		"floor": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return math.Floor(ctx.toNumber(args[0].eval(ctx)))
+		}},
+		"ceiling": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return math.Ceil(ctx.toNumber(args[0].eval(ctx)))
+		}},
+		"round": {1, 1, func(ctx *xpContext, args []xpExpr) xpValue {
+			return xpRound(ctx.toNumber(args[0].eval(ctx)))
+		}},
+	}
+}
+
+// xpRound rounds the number to the closest integer rounding halfs towards
+// positive infinity as required by XPath.
+func xpRound(value float64) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+
+	return math.Floor(value + 0.5)
+}
@@ -0,0 +1,105 @@
+package trees_test
+
+import (
+	"testing"
+
+	"github.com/gu-io/trees"
+)
+
+func TestXPathSelect(t *testing.T) {
+	tree := trees.ParseAsRoot("div#page", `
+    <ul class="menu">
+      <li><a href="/home">Home</a></li>
+      <li class="active"><a href="/about">About us</a></li>
+      <li><a href="/contact">Contact</a></li>
+    </ul>
+    <p lang="en-GB">Price: <b>20</b></p>
+  `)
+
+	cases := []struct {
+		expr  string
+		count int
+		text  string
+	}{
+		{expr: "//li", count: 3},
+		{expr: "//li[2]/a", count: 1, text: "About us"},
+		{expr: "//li[last()]/a", count: 1, text: "Contact"},
+		{expr: "//a[contains(text(), 'About')]", count: 1, text: "About us"},
+		{expr: "//li[@class='active']/following-sibling::li", count: 1, text: "Contact"},
+		{expr: "//li[@class='active']/preceding-sibling::li/a", count: 1, text: "Home"},
+		{expr: "//a[starts-with(@href, '/c')]/ancestor::ul", count: 1},
+		{expr: "ul/li[position() > 1]", count: 2},
+		{expr: "//b/parent::p | //ul", count: 2},
+		{expr: "//p[lang('en')]/b", count: 1, text: "20"},
+		{expr: "/div/p/text()", count: 1, text: "Price:"},
+	}
+
+	for _, tc := range cases {
+		found, err := trees.XPath.Select(tree, tc.expr)
+		if err != nil {
+			t.Fatalf("\t%s\t  Should have evaluated %q: %+q", failed, tc.expr, err)
+		}
+
+		if len(found) != tc.count {
+			t.Fatalf("\t%s\t  Should have found %d markup for %q: %d", failed, tc.count, tc.expr, len(found))
+		}
+
+		if tc.text != "" {
+			value, _ := trees.XPath.Evaluate(found[0], "string(.)")
+			if value != tc.text {
+				t.Fatalf("\t%s\t  Should have found markup with text %q for %q: %q", failed, tc.text, tc.expr, value)
+			}
+		}
+	}
+	t.Logf("\t%s\t  Should have selected markup with xpath expressions", success)
+}
+
+func TestXPathEvaluate(t *testing.T) {
+	tree := trees.ParseAsRoot("div#page", `
+    <ul>
+      <li data-price="10">One</li>
+      <li data-price="15.5">Two</li>
+    </ul>
+  `)
+
+	cases := []struct {
+		expr     string
+		expected interface{}
+	}{
+		{expr: "count(//li)", expected: float64(2)},
+		{expr: "sum(//li/@data-price)", expected: 25.5},
+		{expr: "concat(name(ul), '-', string(//li[1]))", expected: "ul-One"},
+		{expr: "normalize-space('  a   b ')", expected: "a b"},
+		{expr: "substring('12345', 2, 3)", expected: "234"},
+		{expr: "translate('bar', 'abc', 'ABC')", expected: "BAr"},
+		{expr: "//li[2]/@data-price > 15 and not(//li[3])", expected: true},
+		{expr: "7 mod 3 + 4 div 2", expected: float64(3)},
+	}
+
+	for _, tc := range cases {
+		value, err := trees.XPath.Evaluate(tree, tc.expr)
+		if err != nil {
+			t.Fatalf("\t%s\t  Should have evaluated %q: %+q", failed, tc.expr, err)
+		}
+
+		if value != tc.expected {
+			t.Fatalf("\t%s\t  Should have evaluated %q to %v: %v", failed, tc.expr, tc.expected, value)
+		}
+	}
+	t.Logf("\t%s\t  Should have evaluated scalar xpath expressions", success)
+
+	attrs, err := trees.XPath.Evaluate(tree, "//li/@data-price")
+	if props, ok := attrs.([]trees.Property); err != nil || !ok || len(props) != 2 {
+		t.Fatalf("\t%s\t  Should have returned attributes for attribute node-sets: %#v", failed, attrs)
+	}
+	t.Logf("\t%s\t  Should have returned attributes for attribute node-sets", success)
+
+	if _, err := trees.XPath.Compile("//li[@data-price"); err == nil {
+		t.Fatalf("\t%s\t  Should have failed to compile invalid expression", failed)
+	}
+
+	if _, err := trees.XPath.Select(tree, "count(//li)"); err != trees.ErrNotNodeSet {
+		t.Fatalf("\t%s\t  Should have failed to select with scalar expression", failed)
+	}
+	t.Logf("\t%s\t  Should have returned errors for invalid expressions", success)
+}